@@ -0,0 +1,1300 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: api/v1/moppi.proto
+
+/*
+Package moppi is a generated protocol buffer package.
+
+It is generated from these files:
+
+	api/v1/moppi.proto
+
+It has these top-level messages:
+
+	Universe
+	Revision
+	Package
+	InstallRequest
+	Job
+	Empty
+	ListUniversesRequest
+	ListUniversesResponse
+	GetUniverseRequest
+	CreateUniverseRequest
+	DeleteUniverseRequest
+	ListPackagesRequest
+	ListPackagesResponse
+	ListRevisionsRequest
+	ListRevisionsResponse
+	GetPackageRequest
+	PublishPackageRequest
+	PublishPackageResponse
+	YankPackageRequest
+	ListJobsRequest
+	ListJobsResponse
+	GetJobRequest
+*/
+package moppi
+
+import proto "github.com/gogo/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import _ "google.golang.org/genproto/googleapis/api/annotations"
+
+import context "golang.org/x/net/context"
+import grpc "google.golang.org/grpc"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
+
+// *
+// A universe.
+type Universe struct {
+	Id          string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version     string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Name        string `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Href        string `protobuf:"bytes,5,opt,name=href,proto3" json:"href,omitempty"`
+}
+
+func (m *Universe) Reset()                    { *m = Universe{} }
+func (m *Universe) String() string            { return proto.CompactTextString(m) }
+func (*Universe) ProtoMessage()               {}
+func (*Universe) Descriptor() ([]byte, []int) { return fileDescriptorMoppi, []int{0} }
+
+func (m *Universe) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Universe) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *Universe) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Universe) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Universe) GetHref() string {
+	if m != nil {
+		return m.Href
+	}
+	return ""
+}
+
+// *
+// The meta infos of a published package revision.
+type Revision struct {
+	Digest    string `protobuf:"bytes,1,opt,name=digest,proto3" json:"digest,omitempty"`
+	Created   string `protobuf:"bytes,2,opt,name=created,proto3" json:"created,omitempty"`
+	Yanked    bool   `protobuf:"varint,3,opt,name=yanked,proto3" json:"yanked,omitempty"`
+	YankedAt  string `protobuf:"bytes,4,opt,name=yanked_at,json=yankedAt,proto3" json:"yanked_at,omitempty"`
+	Signature string `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (m *Revision) Reset()                    { *m = Revision{} }
+func (m *Revision) String() string            { return proto.CompactTextString(m) }
+func (*Revision) ProtoMessage()               {}
+func (*Revision) Descriptor() ([]byte, []int) { return fileDescriptorMoppi, []int{1} }
+
+func (m *Revision) GetDigest() string {
+	if m != nil {
+		return m.Digest
+	}
+	return ""
+}
+
+func (m *Revision) GetCreated() string {
+	if m != nil {
+		return m.Created
+	}
+	return ""
+}
+
+func (m *Revision) GetYanked() bool {
+	if m != nil {
+		return m.Yanked
+	}
+	return false
+}
+
+func (m *Revision) GetYankedAt() string {
+	if m != nil {
+		return m.YankedAt
+	}
+	return ""
+}
+
+func (m *Revision) GetSignature() string {
+	if m != nil {
+		return m.Signature
+	}
+	return ""
+}
+
+// *
+// A package revision, the document is the canonical JSON of the package.
+type Package struct {
+	Universe string    `protobuf:"bytes,1,opt,name=universe,proto3" json:"universe,omitempty"`
+	Name     string    `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Revision string    `protobuf:"bytes,3,opt,name=revision,proto3" json:"revision,omitempty"`
+	Document string    `protobuf:"bytes,4,opt,name=document,proto3" json:"document,omitempty"`
+	Meta     *Revision `protobuf:"bytes,5,opt,name=meta" json:"meta,omitempty"`
+}
+
+func (m *Package) Reset()                    { *m = Package{} }
+func (m *Package) String() string            { return proto.CompactTextString(m) }
+func (*Package) ProtoMessage()               {}
+func (*Package) Descriptor() ([]byte, []int) { return fileDescriptorMoppi, []int{2} }
+
+func (m *Package) GetUniverse() string {
+	if m != nil {
+		return m.Universe
+	}
+	return ""
+}
+
+func (m *Package) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Package) GetRevision() string {
+	if m != nil {
+		return m.Revision
+	}
+	return ""
+}
+
+func (m *Package) GetDocument() string {
+	if m != nil {
+		return m.Document
+	}
+	return ""
+}
+
+func (m *Package) GetMeta() *Revision {
+	if m != nil {
+		return m.Meta
+	}
+	return nil
+}
+
+// *
+// A request to install or uninstall a package.
+type InstallRequest struct {
+	Universe string `protobuf:"bytes,1,opt,name=universe,proto3" json:"universe,omitempty"`
+	Name     string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Revision string `protobuf:"bytes,3,opt,name=revision,proto3" json:"revision,omitempty"`
+}
+
+func (m *InstallRequest) Reset()                    { *m = InstallRequest{} }
+func (m *InstallRequest) String() string            { return proto.CompactTextString(m) }
+func (*InstallRequest) ProtoMessage()               {}
+func (*InstallRequest) Descriptor() ([]byte, []int) { return fileDescriptorMoppi, []int{3} }
+
+func (m *InstallRequest) GetUniverse() string {
+	if m != nil {
+		return m.Universe
+	}
+	return ""
+}
+
+func (m *InstallRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *InstallRequest) GetRevision() string {
+	if m != nil {
+		return m.Revision
+	}
+	return ""
+}
+
+// *
+// An installment or uninstallment in the queue.
+type Job struct {
+	Id       string          `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind     string          `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Request  *InstallRequest `protobuf:"bytes,3,opt,name=request" json:"request,omitempty"`
+	Identity string          `protobuf:"bytes,4,opt,name=identity,proto3" json:"identity,omitempty"`
+	SourceIp string          `protobuf:"bytes,5,opt,name=source_ip,json=sourceIp,proto3" json:"source_ip,omitempty"`
+	State    string          `protobuf:"bytes,6,opt,name=state,proto3" json:"state,omitempty"`
+	Error    string          `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	Created  string          `protobuf:"bytes,8,opt,name=created,proto3" json:"created,omitempty"`
+	Started  string          `protobuf:"bytes,9,opt,name=started,proto3" json:"started,omitempty"`
+	Finished string          `protobuf:"bytes,10,opt,name=finished,proto3" json:"finished,omitempty"`
+}
+
+func (m *Job) Reset()                    { *m = Job{} }
+func (m *Job) String() string            { return proto.CompactTextString(m) }
+func (*Job) ProtoMessage()               {}
+func (*Job) Descriptor() ([]byte, []int) { return fileDescriptorMoppi, []int{4} }
+
+func (m *Job) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Job) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *Job) GetRequest() *InstallRequest {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *Job) GetIdentity() string {
+	if m != nil {
+		return m.Identity
+	}
+	return ""
+}
+
+func (m *Job) GetSourceIp() string {
+	if m != nil {
+		return m.SourceIp
+	}
+	return ""
+}
+
+func (m *Job) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *Job) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *Job) GetCreated() string {
+	if m != nil {
+		return m.Created
+	}
+	return ""
+}
+
+func (m *Job) GetStarted() string {
+	if m != nil {
+		return m.Started
+	}
+	return ""
+}
+
+func (m *Job) GetFinished() string {
+	if m != nil {
+		return m.Finished
+	}
+	return ""
+}
+
+type Empty struct {
+}
+
+func (m *Empty) Reset()                    { *m = Empty{} }
+func (m *Empty) String() string            { return proto.CompactTextString(m) }
+func (*Empty) ProtoMessage()               {}
+func (*Empty) Descriptor() ([]byte, []int) { return fileDescriptorMoppi, []int{5} }
+
+type ListUniversesRequest struct {
+}
+
+func (m *ListUniversesRequest) Reset()                    { *m = ListUniversesRequest{} }
+func (m *ListUniversesRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListUniversesRequest) ProtoMessage()               {}
+func (*ListUniversesRequest) Descriptor() ([]byte, []int) { return fileDescriptorMoppi, []int{6} }
+
+type ListUniversesResponse struct {
+	Universes []*Universe `protobuf:"bytes,1,rep,name=universes" json:"universes,omitempty"`
+}
+
+func (m *ListUniversesResponse) Reset()                    { *m = ListUniversesResponse{} }
+func (m *ListUniversesResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListUniversesResponse) ProtoMessage()               {}
+func (*ListUniversesResponse) Descriptor() ([]byte, []int) { return fileDescriptorMoppi, []int{7} }
+
+func (m *ListUniversesResponse) GetUniverses() []*Universe {
+	if m != nil {
+		return m.Universes
+	}
+	return nil
+}
+
+type GetUniverseRequest struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (m *GetUniverseRequest) Reset()                    { *m = GetUniverseRequest{} }
+func (m *GetUniverseRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetUniverseRequest) ProtoMessage()               {}
+func (*GetUniverseRequest) Descriptor() ([]byte, []int) { return fileDescriptorMoppi, []int{8} }
+
+func (m *GetUniverseRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type CreateUniverseRequest struct {
+	Universe *Universe `protobuf:"bytes,1,opt,name=universe" json:"universe,omitempty"`
+}
+
+func (m *CreateUniverseRequest) Reset()                    { *m = CreateUniverseRequest{} }
+func (m *CreateUniverseRequest) String() string            { return proto.CompactTextString(m) }
+func (*CreateUniverseRequest) ProtoMessage()               {}
+func (*CreateUniverseRequest) Descriptor() ([]byte, []int) { return fileDescriptorMoppi, []int{9} }
+
+func (m *CreateUniverseRequest) GetUniverse() *Universe {
+	if m != nil {
+		return m.Universe
+	}
+	return nil
+}
+
+type DeleteUniverseRequest struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (m *DeleteUniverseRequest) Reset()                    { *m = DeleteUniverseRequest{} }
+func (m *DeleteUniverseRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteUniverseRequest) ProtoMessage()               {}
+func (*DeleteUniverseRequest) Descriptor() ([]byte, []int) { return fileDescriptorMoppi, []int{10} }
+
+func (m *DeleteUniverseRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type ListPackagesRequest struct {
+	Universe string `protobuf:"bytes,1,opt,name=universe,proto3" json:"universe,omitempty"`
+}
+
+func (m *ListPackagesRequest) Reset()                    { *m = ListPackagesRequest{} }
+func (m *ListPackagesRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListPackagesRequest) ProtoMessage()               {}
+func (*ListPackagesRequest) Descriptor() ([]byte, []int) { return fileDescriptorMoppi, []int{11} }
+
+func (m *ListPackagesRequest) GetUniverse() string {
+	if m != nil {
+		return m.Universe
+	}
+	return ""
+}
+
+type ListPackagesResponse struct {
+	Packages []string `protobuf:"bytes,1,rep,name=packages" json:"packages,omitempty"`
+}
+
+func (m *ListPackagesResponse) Reset()                    { *m = ListPackagesResponse{} }
+func (m *ListPackagesResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListPackagesResponse) ProtoMessage()               {}
+func (*ListPackagesResponse) Descriptor() ([]byte, []int) { return fileDescriptorMoppi, []int{12} }
+
+func (m *ListPackagesResponse) GetPackages() []string {
+	if m != nil {
+		return m.Packages
+	}
+	return nil
+}
+
+type ListRevisionsRequest struct {
+	Universe string `protobuf:"bytes,1,opt,name=universe,proto3" json:"universe,omitempty"`
+	Name     string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (m *ListRevisionsRequest) Reset()                    { *m = ListRevisionsRequest{} }
+func (m *ListRevisionsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListRevisionsRequest) ProtoMessage()               {}
+func (*ListRevisionsRequest) Descriptor() ([]byte, []int) { return fileDescriptorMoppi, []int{13} }
+
+func (m *ListRevisionsRequest) GetUniverse() string {
+	if m != nil {
+		return m.Universe
+	}
+	return ""
+}
+
+func (m *ListRevisionsRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type ListRevisionsResponse struct {
+	Revisions []string `protobuf:"bytes,1,rep,name=revisions" json:"revisions,omitempty"`
+}
+
+func (m *ListRevisionsResponse) Reset()                    { *m = ListRevisionsResponse{} }
+func (m *ListRevisionsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListRevisionsResponse) ProtoMessage()               {}
+func (*ListRevisionsResponse) Descriptor() ([]byte, []int) { return fileDescriptorMoppi, []int{14} }
+
+func (m *ListRevisionsResponse) GetRevisions() []string {
+	if m != nil {
+		return m.Revisions
+	}
+	return nil
+}
+
+type GetPackageRequest struct {
+	Universe string `protobuf:"bytes,1,opt,name=universe,proto3" json:"universe,omitempty"`
+	Name     string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Revision string `protobuf:"bytes,3,opt,name=revision,proto3" json:"revision,omitempty"`
+}
+
+func (m *GetPackageRequest) Reset()                    { *m = GetPackageRequest{} }
+func (m *GetPackageRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetPackageRequest) ProtoMessage()               {}
+func (*GetPackageRequest) Descriptor() ([]byte, []int) { return fileDescriptorMoppi, []int{15} }
+
+func (m *GetPackageRequest) GetUniverse() string {
+	if m != nil {
+		return m.Universe
+	}
+	return ""
+}
+
+func (m *GetPackageRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *GetPackageRequest) GetRevision() string {
+	if m != nil {
+		return m.Revision
+	}
+	return ""
+}
+
+type PublishPackageRequest struct {
+	Universe  string `protobuf:"bytes,1,opt,name=universe,proto3" json:"universe,omitempty"`
+	Name      string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Document  string `protobuf:"bytes,3,opt,name=document,proto3" json:"document,omitempty"`
+	Signature string `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (m *PublishPackageRequest) Reset()                    { *m = PublishPackageRequest{} }
+func (m *PublishPackageRequest) String() string            { return proto.CompactTextString(m) }
+func (*PublishPackageRequest) ProtoMessage()               {}
+func (*PublishPackageRequest) Descriptor() ([]byte, []int) { return fileDescriptorMoppi, []int{16} }
+
+func (m *PublishPackageRequest) GetUniverse() string {
+	if m != nil {
+		return m.Universe
+	}
+	return ""
+}
+
+func (m *PublishPackageRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PublishPackageRequest) GetDocument() string {
+	if m != nil {
+		return m.Document
+	}
+	return ""
+}
+
+func (m *PublishPackageRequest) GetSignature() string {
+	if m != nil {
+		return m.Signature
+	}
+	return ""
+}
+
+type PublishPackageResponse struct {
+	Revision string `protobuf:"bytes,1,opt,name=revision,proto3" json:"revision,omitempty"`
+}
+
+func (m *PublishPackageResponse) Reset()                    { *m = PublishPackageResponse{} }
+func (m *PublishPackageResponse) String() string            { return proto.CompactTextString(m) }
+func (*PublishPackageResponse) ProtoMessage()               {}
+func (*PublishPackageResponse) Descriptor() ([]byte, []int) { return fileDescriptorMoppi, []int{17} }
+
+func (m *PublishPackageResponse) GetRevision() string {
+	if m != nil {
+		return m.Revision
+	}
+	return ""
+}
+
+type YankPackageRequest struct {
+	Universe string `protobuf:"bytes,1,opt,name=universe,proto3" json:"universe,omitempty"`
+	Name     string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Revision string `protobuf:"bytes,3,opt,name=revision,proto3" json:"revision,omitempty"`
+}
+
+func (m *YankPackageRequest) Reset()                    { *m = YankPackageRequest{} }
+func (m *YankPackageRequest) String() string            { return proto.CompactTextString(m) }
+func (*YankPackageRequest) ProtoMessage()               {}
+func (*YankPackageRequest) Descriptor() ([]byte, []int) { return fileDescriptorMoppi, []int{18} }
+
+func (m *YankPackageRequest) GetUniverse() string {
+	if m != nil {
+		return m.Universe
+	}
+	return ""
+}
+
+func (m *YankPackageRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *YankPackageRequest) GetRevision() string {
+	if m != nil {
+		return m.Revision
+	}
+	return ""
+}
+
+type ListJobsRequest struct {
+}
+
+func (m *ListJobsRequest) Reset()                    { *m = ListJobsRequest{} }
+func (m *ListJobsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListJobsRequest) ProtoMessage()               {}
+func (*ListJobsRequest) Descriptor() ([]byte, []int) { return fileDescriptorMoppi, []int{19} }
+
+type ListJobsResponse struct {
+	Jobs []*Job `protobuf:"bytes,1,rep,name=jobs" json:"jobs,omitempty"`
+}
+
+func (m *ListJobsResponse) Reset()                    { *m = ListJobsResponse{} }
+func (m *ListJobsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListJobsResponse) ProtoMessage()               {}
+func (*ListJobsResponse) Descriptor() ([]byte, []int) { return fileDescriptorMoppi, []int{20} }
+
+func (m *ListJobsResponse) GetJobs() []*Job {
+	if m != nil {
+		return m.Jobs
+	}
+	return nil
+}
+
+type GetJobRequest struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (m *GetJobRequest) Reset()                    { *m = GetJobRequest{} }
+func (m *GetJobRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetJobRequest) ProtoMessage()               {}
+func (*GetJobRequest) Descriptor() ([]byte, []int) { return fileDescriptorMoppi, []int{21} }
+
+func (m *GetJobRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*Universe)(nil), "moppi.v1.Universe")
+	proto.RegisterType((*Revision)(nil), "moppi.v1.Revision")
+	proto.RegisterType((*Package)(nil), "moppi.v1.Package")
+	proto.RegisterType((*InstallRequest)(nil), "moppi.v1.InstallRequest")
+	proto.RegisterType((*Job)(nil), "moppi.v1.Job")
+	proto.RegisterType((*Empty)(nil), "moppi.v1.Empty")
+	proto.RegisterType((*ListUniversesRequest)(nil), "moppi.v1.ListUniversesRequest")
+	proto.RegisterType((*ListUniversesResponse)(nil), "moppi.v1.ListUniversesResponse")
+	proto.RegisterType((*GetUniverseRequest)(nil), "moppi.v1.GetUniverseRequest")
+	proto.RegisterType((*CreateUniverseRequest)(nil), "moppi.v1.CreateUniverseRequest")
+	proto.RegisterType((*DeleteUniverseRequest)(nil), "moppi.v1.DeleteUniverseRequest")
+	proto.RegisterType((*ListPackagesRequest)(nil), "moppi.v1.ListPackagesRequest")
+	proto.RegisterType((*ListPackagesResponse)(nil), "moppi.v1.ListPackagesResponse")
+	proto.RegisterType((*ListRevisionsRequest)(nil), "moppi.v1.ListRevisionsRequest")
+	proto.RegisterType((*ListRevisionsResponse)(nil), "moppi.v1.ListRevisionsResponse")
+	proto.RegisterType((*GetPackageRequest)(nil), "moppi.v1.GetPackageRequest")
+	proto.RegisterType((*PublishPackageRequest)(nil), "moppi.v1.PublishPackageRequest")
+	proto.RegisterType((*PublishPackageResponse)(nil), "moppi.v1.PublishPackageResponse")
+	proto.RegisterType((*YankPackageRequest)(nil), "moppi.v1.YankPackageRequest")
+	proto.RegisterType((*ListJobsRequest)(nil), "moppi.v1.ListJobsRequest")
+	proto.RegisterType((*ListJobsResponse)(nil), "moppi.v1.ListJobsResponse")
+	proto.RegisterType((*GetJobRequest)(nil), "moppi.v1.GetJobRequest")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Universes service
+
+type UniversesClient interface {
+	ListUniverses(ctx context.Context, in *ListUniversesRequest, opts ...grpc.CallOption) (*ListUniversesResponse, error)
+	GetUniverse(ctx context.Context, in *GetUniverseRequest, opts ...grpc.CallOption) (*Universe, error)
+	CreateUniverse(ctx context.Context, in *CreateUniverseRequest, opts ...grpc.CallOption) (*Universe, error)
+	DeleteUniverse(ctx context.Context, in *DeleteUniverseRequest, opts ...grpc.CallOption) (*Empty, error)
+}
+
+type universesClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewUniversesClient(cc *grpc.ClientConn) UniversesClient {
+	return &universesClient{cc}
+}
+
+func (c *universesClient) ListUniverses(ctx context.Context, in *ListUniversesRequest, opts ...grpc.CallOption) (*ListUniversesResponse, error) {
+	out := new(ListUniversesResponse)
+	err := grpc.Invoke(ctx, "/moppi.v1.Universes/ListUniverses", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *universesClient) GetUniverse(ctx context.Context, in *GetUniverseRequest, opts ...grpc.CallOption) (*Universe, error) {
+	out := new(Universe)
+	err := grpc.Invoke(ctx, "/moppi.v1.Universes/GetUniverse", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *universesClient) CreateUniverse(ctx context.Context, in *CreateUniverseRequest, opts ...grpc.CallOption) (*Universe, error) {
+	out := new(Universe)
+	err := grpc.Invoke(ctx, "/moppi.v1.Universes/CreateUniverse", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *universesClient) DeleteUniverse(ctx context.Context, in *DeleteUniverseRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/moppi.v1.Universes/DeleteUniverse", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Universes service
+
+type UniversesServer interface {
+	ListUniverses(context.Context, *ListUniversesRequest) (*ListUniversesResponse, error)
+	GetUniverse(context.Context, *GetUniverseRequest) (*Universe, error)
+	CreateUniverse(context.Context, *CreateUniverseRequest) (*Universe, error)
+	DeleteUniverse(context.Context, *DeleteUniverseRequest) (*Empty, error)
+}
+
+func RegisterUniversesServer(s *grpc.Server, srv UniversesServer) {
+	s.RegisterService(&_Universes_serviceDesc, srv)
+}
+
+func _Universes_ListUniverses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUniversesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UniversesServer).ListUniverses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/moppi.v1.Universes/ListUniverses",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UniversesServer).ListUniverses(ctx, req.(*ListUniversesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Universes_GetUniverse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUniverseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UniversesServer).GetUniverse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/moppi.v1.Universes/GetUniverse",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UniversesServer).GetUniverse(ctx, req.(*GetUniverseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Universes_CreateUniverse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUniverseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UniversesServer).CreateUniverse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/moppi.v1.Universes/CreateUniverse",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UniversesServer).CreateUniverse(ctx, req.(*CreateUniverseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Universes_DeleteUniverse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUniverseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UniversesServer).DeleteUniverse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/moppi.v1.Universes/DeleteUniverse",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UniversesServer).DeleteUniverse(ctx, req.(*DeleteUniverseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Universes_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "moppi.v1.Universes",
+	HandlerType: (*UniversesServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListUniverses",
+			Handler:    _Universes_ListUniverses_Handler,
+		},
+		{
+			MethodName: "GetUniverse",
+			Handler:    _Universes_GetUniverse_Handler,
+		},
+		{
+			MethodName: "CreateUniverse",
+			Handler:    _Universes_CreateUniverse_Handler,
+		},
+		{
+			MethodName: "DeleteUniverse",
+			Handler:    _Universes_DeleteUniverse_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/v1/moppi.proto",
+}
+
+// Client API for Packages service
+
+type PackagesClient interface {
+	ListPackages(ctx context.Context, in *ListPackagesRequest, opts ...grpc.CallOption) (*ListPackagesResponse, error)
+	ListRevisions(ctx context.Context, in *ListRevisionsRequest, opts ...grpc.CallOption) (*ListRevisionsResponse, error)
+	GetPackage(ctx context.Context, in *GetPackageRequest, opts ...grpc.CallOption) (*Package, error)
+	PublishPackage(ctx context.Context, in *PublishPackageRequest, opts ...grpc.CallOption) (*PublishPackageResponse, error)
+	YankPackage(ctx context.Context, in *YankPackageRequest, opts ...grpc.CallOption) (*Empty, error)
+}
+
+type packagesClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewPackagesClient(cc *grpc.ClientConn) PackagesClient {
+	return &packagesClient{cc}
+}
+
+func (c *packagesClient) ListPackages(ctx context.Context, in *ListPackagesRequest, opts ...grpc.CallOption) (*ListPackagesResponse, error) {
+	out := new(ListPackagesResponse)
+	err := grpc.Invoke(ctx, "/moppi.v1.Packages/ListPackages", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *packagesClient) ListRevisions(ctx context.Context, in *ListRevisionsRequest, opts ...grpc.CallOption) (*ListRevisionsResponse, error) {
+	out := new(ListRevisionsResponse)
+	err := grpc.Invoke(ctx, "/moppi.v1.Packages/ListRevisions", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *packagesClient) GetPackage(ctx context.Context, in *GetPackageRequest, opts ...grpc.CallOption) (*Package, error) {
+	out := new(Package)
+	err := grpc.Invoke(ctx, "/moppi.v1.Packages/GetPackage", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *packagesClient) PublishPackage(ctx context.Context, in *PublishPackageRequest, opts ...grpc.CallOption) (*PublishPackageResponse, error) {
+	out := new(PublishPackageResponse)
+	err := grpc.Invoke(ctx, "/moppi.v1.Packages/PublishPackage", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *packagesClient) YankPackage(ctx context.Context, in *YankPackageRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/moppi.v1.Packages/YankPackage", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Packages service
+
+type PackagesServer interface {
+	ListPackages(context.Context, *ListPackagesRequest) (*ListPackagesResponse, error)
+	ListRevisions(context.Context, *ListRevisionsRequest) (*ListRevisionsResponse, error)
+	GetPackage(context.Context, *GetPackageRequest) (*Package, error)
+	PublishPackage(context.Context, *PublishPackageRequest) (*PublishPackageResponse, error)
+	YankPackage(context.Context, *YankPackageRequest) (*Empty, error)
+}
+
+func RegisterPackagesServer(s *grpc.Server, srv PackagesServer) {
+	s.RegisterService(&_Packages_serviceDesc, srv)
+}
+
+func _Packages_ListPackages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPackagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PackagesServer).ListPackages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/moppi.v1.Packages/ListPackages",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PackagesServer).ListPackages(ctx, req.(*ListPackagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Packages_ListRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PackagesServer).ListRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/moppi.v1.Packages/ListRevisions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PackagesServer).ListRevisions(ctx, req.(*ListRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Packages_GetPackage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPackageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PackagesServer).GetPackage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/moppi.v1.Packages/GetPackage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PackagesServer).GetPackage(ctx, req.(*GetPackageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Packages_PublishPackage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishPackageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PackagesServer).PublishPackage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/moppi.v1.Packages/PublishPackage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PackagesServer).PublishPackage(ctx, req.(*PublishPackageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Packages_YankPackage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(YankPackageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PackagesServer).YankPackage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/moppi.v1.Packages/YankPackage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PackagesServer).YankPackage(ctx, req.(*YankPackageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Packages_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "moppi.v1.Packages",
+	HandlerType: (*PackagesServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListPackages",
+			Handler:    _Packages_ListPackages_Handler,
+		},
+		{
+			MethodName: "ListRevisions",
+			Handler:    _Packages_ListRevisions_Handler,
+		},
+		{
+			MethodName: "GetPackage",
+			Handler:    _Packages_GetPackage_Handler,
+		},
+		{
+			MethodName: "PublishPackage",
+			Handler:    _Packages_PublishPackage_Handler,
+		},
+		{
+			MethodName: "YankPackage",
+			Handler:    _Packages_YankPackage_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/v1/moppi.proto",
+}
+
+// Client API for Installs service
+
+type InstallsClient interface {
+	Install(ctx context.Context, in *InstallRequest, opts ...grpc.CallOption) (*Job, error)
+	Uninstall(ctx context.Context, in *InstallRequest, opts ...grpc.CallOption) (*Job, error)
+}
+
+type installsClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewInstallsClient(cc *grpc.ClientConn) InstallsClient {
+	return &installsClient{cc}
+}
+
+func (c *installsClient) Install(ctx context.Context, in *InstallRequest, opts ...grpc.CallOption) (*Job, error) {
+	out := new(Job)
+	err := grpc.Invoke(ctx, "/moppi.v1.Installs/Install", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *installsClient) Uninstall(ctx context.Context, in *InstallRequest, opts ...grpc.CallOption) (*Job, error) {
+	out := new(Job)
+	err := grpc.Invoke(ctx, "/moppi.v1.Installs/Uninstall", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Installs service
+
+type InstallsServer interface {
+	Install(context.Context, *InstallRequest) (*Job, error)
+	Uninstall(context.Context, *InstallRequest) (*Job, error)
+}
+
+func RegisterInstallsServer(s *grpc.Server, srv InstallsServer) {
+	s.RegisterService(&_Installs_serviceDesc, srv)
+}
+
+func _Installs_Install_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InstallRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InstallsServer).Install(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/moppi.v1.Installs/Install",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InstallsServer).Install(ctx, req.(*InstallRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Installs_Uninstall_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InstallRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InstallsServer).Uninstall(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/moppi.v1.Installs/Uninstall",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InstallsServer).Uninstall(ctx, req.(*InstallRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Installs_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "moppi.v1.Installs",
+	HandlerType: (*InstallsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Install",
+			Handler:    _Installs_Install_Handler,
+		},
+		{
+			MethodName: "Uninstall",
+			Handler:    _Installs_Uninstall_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/v1/moppi.proto",
+}
+
+// Client API for Jobs service
+
+type JobsClient interface {
+	ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error)
+	GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*Job, error)
+}
+
+type jobsClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewJobsClient(cc *grpc.ClientConn) JobsClient {
+	return &jobsClient{cc}
+}
+
+func (c *jobsClient) ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error) {
+	out := new(ListJobsResponse)
+	err := grpc.Invoke(ctx, "/moppi.v1.Jobs/ListJobs", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobsClient) GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*Job, error) {
+	out := new(Job)
+	err := grpc.Invoke(ctx, "/moppi.v1.Jobs/GetJob", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Jobs service
+
+type JobsServer interface {
+	ListJobs(context.Context, *ListJobsRequest) (*ListJobsResponse, error)
+	GetJob(context.Context, *GetJobRequest) (*Job, error)
+}
+
+func RegisterJobsServer(s *grpc.Server, srv JobsServer) {
+	s.RegisterService(&_Jobs_serviceDesc, srv)
+}
+
+func _Jobs_ListJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobsServer).ListJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/moppi.v1.Jobs/ListJobs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobsServer).ListJobs(ctx, req.(*ListJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Jobs_GetJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobsServer).GetJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/moppi.v1.Jobs/GetJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobsServer).GetJob(ctx, req.(*GetJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Jobs_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "moppi.v1.Jobs",
+	HandlerType: (*JobsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListJobs",
+			Handler:    _Jobs_ListJobs_Handler,
+		},
+		{
+			MethodName: "GetJob",
+			Handler:    _Jobs_GetJob_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/v1/moppi.proto",
+}
+
+func init() { proto.RegisterFile("api/v1/moppi.proto", fileDescriptorMoppi) }
+
+var fileDescriptorMoppi = []byte{
+	// 1067 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x57, 0x5b, 0x6f, 0x1b, 0x45,
+	0x14, 0xd6, 0x3a, 0x4e, 0xbc, 0x3e, 0x26, 0xb7, 0x43, 0x1c, 0xb6, 0xdb, 0xd0, 0xa4, 0x03, 0x82,
+	0x62, 0x81, 0xdd, 0x38, 0xe4, 0xa5, 0x7d, 0xe2, 0x6a, 0x25, 0x42, 0xa8, 0x32, 0xe2, 0x81, 0x8b,
+	0x08, 0x6b, 0xef, 0xc4, 0x19, 0x62, 0xef, 0x2e, 0x3b, 0xe3, 0xa8, 0x56, 0x14, 0x24, 0xfa, 0x84,
+	0x78, 0x41, 0x82, 0xa7, 0xbe, 0x23, 0xfe, 0x10, 0x7f, 0x81, 0x1f, 0x82, 0x66, 0x76, 0x66, 0x6f,
+	0xb1, 0xd5, 0x50, 0xda, 0xb7, 0x39, 0xe7, 0xcc, 0x9e, 0xef, 0x9b, 0x73, 0xf9, 0x12, 0x03, 0x7a,
+	0x11, 0xeb, 0x5c, 0xec, 0x77, 0x26, 0x61, 0x14, 0xb1, 0x76, 0x14, 0x87, 0x22, 0x44, 0x3b, 0x31,
+	0x2e, 0xf6, 0xdd, 0x9d, 0x51, 0x18, 0x8e, 0xc6, 0xb4, 0x23, 0x2f, 0x79, 0x41, 0x10, 0x0a, 0x4f,
+	0xb0, 0x30, 0xe0, 0xc9, 0x3d, 0xf2, 0x13, 0xd8, 0x5f, 0x06, 0xec, 0x82, 0xc6, 0x9c, 0xe2, 0x1a,
+	0x54, 0x98, 0xef, 0x58, 0x7b, 0xd6, 0xbd, 0x7a, 0xbf, 0xc2, 0x7c, 0x74, 0xa0, 0x26, 0x03, 0x2c,
+	0x0c, 0x9c, 0x8a, 0x72, 0x1a, 0x13, 0xf7, 0xa0, 0xe1, 0x53, 0x3e, 0x8c, 0x59, 0x24, 0x73, 0x39,
+	0x4b, 0x2a, 0x9a, 0x77, 0x21, 0x42, 0x35, 0xf0, 0x26, 0xd4, 0xa9, 0xaa, 0x90, 0x3a, 0x4b, 0xdf,
+	0x59, 0x4c, 0x4f, 0x9d, 0xe5, 0xc4, 0x27, 0xcf, 0xe4, 0x37, 0x0b, 0xec, 0x3e, 0xbd, 0x60, 0x2a,
+	0xed, 0x36, 0xac, 0xf8, 0x6c, 0x44, 0xb9, 0xd0, 0x24, 0xb4, 0x25, 0x89, 0x0c, 0x63, 0xea, 0x09,
+	0xea, 0x1b, 0x22, 0xda, 0x94, 0x5f, 0xcc, 0xbc, 0xe0, 0x9c, 0xfa, 0x8a, 0x83, 0xdd, 0xd7, 0x16,
+	0xde, 0x86, 0x7a, 0x72, 0x3a, 0xf1, 0x84, 0xe6, 0x60, 0x27, 0x8e, 0x0f, 0x04, 0xee, 0x40, 0x9d,
+	0xb3, 0x51, 0xe0, 0x89, 0x69, 0x4c, 0x35, 0x99, 0xcc, 0x41, 0x9e, 0x5a, 0x50, 0x7b, 0xe4, 0x0d,
+	0xcf, 0xbd, 0x11, 0x45, 0x17, 0xec, 0xa9, 0xae, 0x8e, 0xa6, 0x94, 0xda, 0xe9, 0x0b, 0x2b, 0xb9,
+	0x17, 0xba, 0x60, 0xc7, 0xfa, 0x31, 0xba, 0x28, 0xa9, 0x2d, 0x63, 0x7e, 0x38, 0x9c, 0x4e, 0x68,
+	0x90, 0x32, 0x32, 0x36, 0xbe, 0x05, 0xd5, 0x09, 0x15, 0x9e, 0x22, 0xd3, 0xe8, 0x62, 0xdb, 0x34,
+	0xaf, 0x6d, 0x4a, 0xd3, 0x57, 0x71, 0xf2, 0x2d, 0xac, 0x1d, 0x05, 0x5c, 0x78, 0xe3, 0x71, 0x9f,
+	0xfe, 0x38, 0x95, 0xa5, 0x79, 0x81, 0x0c, 0xc9, 0xef, 0x15, 0x58, 0x3a, 0x0e, 0x07, 0xd7, 0xe6,
+	0x00, 0xa1, 0x7a, 0xce, 0x02, 0x53, 0x7b, 0x75, 0xc6, 0x2e, 0xd4, 0xe2, 0x84, 0x82, 0x4a, 0xd3,
+	0xe8, 0x3a, 0x19, 0xe9, 0x22, 0xc5, 0xbe, 0xb9, 0x28, 0xb1, 0x99, 0x4f, 0x03, 0xc1, 0xc4, 0xcc,
+	0x54, 0xc0, 0xd8, 0xb2, 0x61, 0x3c, 0x9c, 0xc6, 0x43, 0x7a, 0xc2, 0x22, 0xdd, 0x13, 0x3b, 0x71,
+	0x1c, 0x45, 0xb8, 0x05, 0xcb, 0x5c, 0x78, 0x82, 0x3a, 0x2b, 0x2a, 0x90, 0x18, 0xd2, 0x4b, 0xe3,
+	0x38, 0x8c, 0x9d, 0x5a, 0xe2, 0x55, 0x46, 0x7e, 0x56, 0xec, 0xe2, 0xac, 0x38, 0x50, 0xe3, 0xc2,
+	0x8b, 0x65, 0xa4, 0x9e, 0x44, 0xb4, 0x29, 0x89, 0x9d, 0xb2, 0x80, 0xf1, 0x33, 0xea, 0x3b, 0x90,
+	0x60, 0x1b, 0x9b, 0xd4, 0x60, 0xf9, 0x93, 0x49, 0x24, 0x66, 0x64, 0x1b, 0xb6, 0x3e, 0x63, 0x5c,
+	0x98, 0x6d, 0xe1, 0xfa, 0x79, 0xe4, 0x08, 0x9a, 0x25, 0x3f, 0x8f, 0xc2, 0x80, 0x53, 0xbc, 0x0f,
+	0x75, 0xd3, 0x0a, 0xee, 0x58, 0x7b, 0x4b, 0xc5, 0xce, 0x9a, 0xfb, 0xfd, 0xec, 0x12, 0x79, 0x13,
+	0xb0, 0x47, 0xd3, 0x4c, 0xa6, 0xc5, 0xa5, 0x76, 0x90, 0x1e, 0x34, 0x3f, 0x52, 0x4f, 0x2a, 0x5f,
+	0x6c, 0x97, 0x66, 0x61, 0x3e, 0x5e, 0x7a, 0x87, 0xbc, 0x0d, 0xcd, 0x8f, 0xe9, 0x98, 0x0a, 0xfa,
+	0x2c, 0xc4, 0x7d, 0x78, 0x55, 0x3e, 0x51, 0x6f, 0x05, 0xbf, 0xc1, 0xec, 0x91, 0x2e, 0x6c, 0x15,
+	0x3f, 0xd1, 0x45, 0x71, 0xc1, 0x8e, 0xb4, 0x4f, 0xd5, 0xa4, 0xde, 0x4f, 0x6d, 0xf2, 0x69, 0xf2,
+	0x8d, 0x99, 0x79, 0xfe, 0x9c, 0x33, 0x4e, 0x0e, 0xa1, 0x59, 0xca, 0xa3, 0xc1, 0x77, 0xa0, 0x6e,
+	0x86, 0xdd, 0xa0, 0x67, 0x0e, 0x72, 0x02, 0x9b, 0x3d, 0x6a, 0x18, 0xbf, 0x8c, 0xfd, 0xfa, 0xd9,
+	0x82, 0xe6, 0xa3, 0xe9, 0x60, 0xcc, 0xf8, 0xd9, 0xff, 0x47, 0x49, 0xb5, 0x64, 0xa9, 0xa4, 0x25,
+	0x05, 0x75, 0xab, 0x96, 0xd5, 0xed, 0x7d, 0xd8, 0x2e, 0x53, 0xc8, 0x3a, 0x93, 0x32, 0xb7, 0x4a,
+	0xcc, 0xbf, 0x07, 0xfc, 0xca, 0x0b, 0xce, 0x5f, 0x62, 0x6d, 0x36, 0x61, 0x5d, 0xf6, 0xec, 0x38,
+	0x1c, 0xa4, 0x8b, 0x75, 0x08, 0x1b, 0x99, 0x4b, 0x93, 0xbc, 0x0b, 0xd5, 0x1f, 0xc2, 0x81, 0x59,
+	0xa7, 0xd5, 0x6c, 0xbc, 0x8f, 0xc3, 0x41, 0x5f, 0x85, 0xc8, 0x2e, 0xac, 0xf6, 0xa8, 0xfc, 0x6a,
+	0xc1, 0x34, 0x77, 0x9f, 0x2e, 0x41, 0x3d, 0xdd, 0x56, 0x1c, 0xc1, 0x6a, 0x61, 0x7d, 0xf1, 0x4e,
+	0x96, 0x74, 0xde, 0xbe, 0xbb, 0xbb, 0x0b, 0xe3, 0x09, 0x47, 0xd2, 0x7c, 0xf2, 0xf7, 0x3f, 0x7f,
+	0x54, 0xd6, 0x71, 0x55, 0xfe, 0x4d, 0x4e, 0x97, 0x1b, 0xbf, 0x81, 0x46, 0x6e, 0xb9, 0x71, 0x27,
+	0x4b, 0x73, 0x7d, 0xe7, 0xdd, 0x39, 0x8b, 0x4b, 0x5c, 0x95, 0x77, 0x0b, 0xb1, 0x90, 0xb7, 0x73,
+	0xc9, 0xfc, 0x2b, 0x3c, 0x85, 0xb5, 0xa2, 0x26, 0x60, 0x8e, 0xe6, 0x5c, 0xb5, 0x98, 0x0b, 0xb1,
+	0xab, 0x20, 0x6e, 0x3d, 0xc8, 0xf6, 0xb8, 0xf4, 0x88, 0xef, 0x60, 0xad, 0x28, 0x19, 0x79, 0x9c,
+	0xb9, 0x62, 0xe2, 0xae, 0x67, 0x17, 0x12, 0x21, 0xd5, 0xef, 0x68, 0xcd, 0x79, 0x47, 0xf7, 0xd7,
+	0x65, 0xb0, 0x8d, 0x66, 0xe0, 0x63, 0x78, 0x25, 0xaf, 0x21, 0xf8, 0x7a, 0xb1, 0xf2, 0x25, 0x39,
+	0x72, 0xef, 0x2c, 0x0a, 0xeb, 0xbe, 0xbc, 0xa3, 0x70, 0xdf, 0xc0, 0xbb, 0x25, 0x5c, 0x73, 0xbc,
+	0xea, 0x18, 0x25, 0xc2, 0x27, 0x16, 0xac, 0x16, 0x24, 0xa4, 0x3c, 0x15, 0x65, 0x8d, 0x72, 0x77,
+	0x17, 0xc6, 0x35, 0xfa, 0x7d, 0x85, 0xde, 0xc2, 0x7b, 0xcf, 0x44, 0xef, 0x5c, 0xca, 0x6d, 0xb9,
+	0xc2, 0x19, 0x40, 0xa6, 0x47, 0x78, 0xbb, 0x30, 0x2f, 0xc5, 0x4d, 0x74, 0x37, 0xb3, 0xa0, 0x8e,
+	0x90, 0x87, 0x0a, 0xef, 0x10, 0x0f, 0x6e, 0x8a, 0xd7, 0xb9, 0x34, 0xcb, 0x78, 0x85, 0xbf, 0x58,
+	0xb0, 0x56, 0x94, 0x89, 0x7c, 0x9f, 0xe7, 0x6a, 0x98, 0xbb, 0xb7, 0xf8, 0x82, 0x2e, 0xc1, 0x81,
+	0xa2, 0xf4, 0x1e, 0xb9, 0x71, 0x09, 0x1e, 0x58, 0x2d, 0x9c, 0x41, 0x23, 0x27, 0x3d, 0xf9, 0xb5,
+	0xb9, 0xae, 0x48, 0xd7, 0x67, 0x4d, 0x57, 0xa1, 0xf5, 0x3c, 0x55, 0xe8, 0xfe, 0x65, 0x81, 0xad,
+	0xff, 0x97, 0xe1, 0x78, 0x0c, 0x35, 0x7d, 0xc6, 0x85, 0xff, 0xea, 0xb8, 0x45, 0x41, 0x22, 0xdb,
+	0x0a, 0x7d, 0x83, 0x34, 0x24, 0x3a, 0x4b, 0xae, 0xca, 0x37, 0x7d, 0xae, 0x04, 0xe8, 0xbf, 0x66,
+	0x73, 0x54, 0x36, 0x4c, 0x57, 0x32, 0xcd, 0xd7, 0xfd, 0xd3, 0x82, 0xaa, 0x94, 0x49, 0xfc, 0x02,
+	0x6c, 0x23, 0x99, 0x78, 0xab, 0x38, 0x91, 0x39, 0x65, 0x75, 0xdd, 0x79, 0x21, 0xdd, 0xa4, 0x0d,
+	0x85, 0x02, 0x68, 0x4b, 0x14, 0x29, 0xa8, 0xd8, 0x83, 0x95, 0x44, 0x50, 0xf1, 0xb5, 0xc2, 0x0c,
+	0x66, 0x12, 0x5b, 0x66, 0x5a, 0x50, 0x40, 0x99, 0x43, 0x2d, 0xf7, 0x87, 0xef, 0x7e, 0xdd, 0x1a,
+	0x31, 0x71, 0x36, 0x1d, 0xb4, 0x87, 0xe1, 0xa4, 0xe3, 0x3d, 0xa6, 0x63, 0x1e, 0xc5, 0x2c, 0x18,
+	0xd1, 0x38, 0xf9, 0xe9, 0xd2, 0x49, 0x7e, 0xc7, 0x3c, 0x54, 0xc6, 0x60, 0x45, 0xfd, 0x40, 0x39,
+	0xf8, 0x77, 0x00, 0x72, 0xf7, 0x81, 0xb6, 0xde, 0x0c, 0x00, 0x00,
+}
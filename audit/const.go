@@ -0,0 +1,30 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+const (
+	// ResultSuccess is the result of a successful call or job
+	ResultSuccess = "success"
+	// ResultFailure is the result of a failed call or job
+	ResultFailure = "failure"
+)
+
+const (
+	defaultRetention = 10000
+	// pruneInterval is the number of records after which the log is pruned
+	pruneInterval = 100
+	// buffer is the number of entries, that wait to be written
+	buffer = 1000
+)
@@ -0,0 +1,178 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/axelspringer/moppi/cfg"
+	"github.com/axelspringer/moppi/provider"
+)
+
+// Record appends an entry to the audit log, and mirrors it to the file.
+// The entry is written in the background, the call never waits. Entries are
+// dropped when the buffer is full (e.g. the KV is down). Failures are logged,
+// as they should never fail the audited call.
+func (l *Log) Record(entry *provider.AuditEntry) {
+	if entry.Time.IsZero() {
+		entry.Time = time.Now().UTC()
+	}
+	entry.ID = newID(entry.Time)
+
+	l.mu.Lock()
+	closed := l.closed
+	l.mu.Unlock()
+
+	if closed {
+		cfg.Log.Errorf("Could not record audit entry %s, the audit log is closed", entry.ID)
+		return
+	}
+
+	select {
+	case l.entries <- entry:
+	default:
+		cfg.Log.Errorf("Could not record audit entry %s, the audit log is full", entry.ID)
+	}
+}
+
+// write writes the recorded entries, and the buffered ones once the log is closed
+func (l *Log) write() {
+	defer close(l.done)
+
+	for {
+		select {
+		case entry := <-l.entries:
+			l.persist(entry)
+		case <-l.quit:
+			for {
+				select {
+				case entry := <-l.entries:
+					l.persist(entry)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// persist writes an entry, and prunes the log from time to time
+func (l *Log) persist(entry *provider.AuditEntry) {
+	if err := l.store.CreateAuditEntry(entry); err != nil {
+		cfg.Log.WithError(err).Errorf("Could not record audit entry %s", entry.ID)
+	}
+
+	if l.file != nil {
+		line, _ := json.Marshal(entry)
+		if _, err := l.file.Write(append(line, '\n')); err != nil {
+			cfg.Log.WithError(err).Errorf("Could not mirror audit entry %s", entry.ID)
+		}
+	}
+
+	l.records++
+	if l.records%pruneInterval == 0 {
+		if err := l.prune(); err != nil {
+			cfg.Log.WithError(err).Warnf("Could not prune the audit log")
+		}
+	}
+}
+
+// Query returns the entries matching the filter, newest first
+func (l *Log) Query(filter *Filter) ([]*provider.AuditEntry, error) {
+	entries, err := l.store.GetAuditEntries()
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]*provider.AuditEntry, 0)
+	for i := len(entries) - 1; i >= 0; i-- {
+		if filter.Limit > 0 && len(matches) >= filter.Limit {
+			break
+		}
+
+		if filter.match(entries[i]) {
+			matches = append(matches, entries[i])
+		}
+	}
+
+	return matches, nil
+}
+
+// Close writes the entries, that are still buffered, and closes the mirror file
+func (l *Log) Close() error {
+	l.mu.Lock()
+	if !l.closed {
+		l.closed = true
+		close(l.quit)
+	}
+	l.mu.Unlock()
+
+	<-l.done
+
+	if l.file == nil {
+		return nil
+	}
+
+	return l.file.Close()
+}
+
+// prune deletes the oldest entries beyond the retention
+func (l *Log) prune() error {
+	entries, err := l.store.GetAuditEntries()
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < len(entries)-l.retention; i++ {
+		if err := l.store.DeleteAuditEntry(entries[i].ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// match returns if an entry matches the filter
+func (f *Filter) match(entry *provider.AuditEntry) bool {
+	switch {
+	case f.Universe != "" && !strings.EqualFold(f.Universe, entry.Universe):
+		return false
+	case f.Identity != "" && f.Identity != entry.Identity:
+		return false
+	case f.Action != "" && !strings.HasPrefix(entry.Action, f.Action):
+		return false
+	case f.Result != "" && f.Result != entry.Result:
+		return false
+	case !f.Since.IsZero() && entry.Time.Before(f.Since):
+		return false
+	case !f.Until.IsZero() && entry.Time.After(f.Until):
+		return false
+	}
+
+	return true
+}
+
+// newID returns an id, that sorts by time
+func newID(t time.Time) string {
+	b := make([]byte, 4)
+	rand.Read(b)
+
+	return fmt.Sprintf("%019d-%s", t.UnixNano(), hex.EncodeToString(b))
+}
@@ -0,0 +1,191 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/axelspringer/moppi/audit"
+	"github.com/axelspringer/moppi/cfg"
+	"github.com/axelspringer/moppi/provider"
+)
+
+// store is an audit store in memory, the writes wait for the gate
+type store struct {
+	entries []*provider.AuditEntry
+	gate    chan struct{}
+	mu      sync.Mutex
+}
+
+func (s *store) CreateAuditEntry(entry *provider.AuditEntry) error {
+	if s.gate != nil {
+		<-s.gate
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+func (s *store) GetAuditEntries() ([]*provider.AuditEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*provider.AuditEntry{}, s.entries...), nil
+}
+
+func (s *store) DeleteAuditEntry(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, entry := range s.entries {
+		if entry.ID == id {
+			s.entries = append(s.entries[:i], s.entries[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (s *store) len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.entries)
+}
+
+var _ = Describe("Log", func() {
+	var (
+		kv  *store
+		log *audit.Log
+	)
+
+	BeforeEach(func() {
+		var err error
+		kv = &store{}
+		log, err = audit.New(&cfg.Config{Audit: cfg.Audit{Retention: 5}}, kv)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		log.Close()
+	})
+
+	It("records the entries, and queries them newest first", func() {
+		log.Record(&provider.AuditEntry{Action: "install", Universe: "prod", Identity: "ci", Result: audit.ResultSuccess})
+		log.Record(&provider.AuditEntry{Action: "install", Universe: "dev", Identity: "ci", Result: audit.ResultFailure})
+		log.Record(&provider.AuditEntry{Action: "uninstall", Universe: "PROD", Identity: "ops", Result: audit.ResultSuccess})
+		Eventually(kv.len).Should(Equal(3))
+
+		entries, err := log.Query(&audit.Filter{Universe: "prod"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(2))
+		Expect(entries[0].Action).To(Equal("uninstall"))
+		Expect(entries[0].ID).NotTo(BeEmpty())
+		Expect(entries[0].Time.IsZero()).To(BeFalse())
+
+		entries, err = log.Query(&audit.Filter{Identity: "ci", Result: audit.ResultFailure})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+		Expect(entries[0].Universe).To(Equal("dev"))
+
+		entries, err = log.Query(&audit.Filter{Limit: 1})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+	})
+
+	It("does not wait for the store", func() {
+		kv.gate = make(chan struct{})
+		defer close(kv.gate)
+
+		recorded := make(chan struct{})
+		go func() {
+			for i := 0; i < 10; i++ {
+				log.Record(&provider.AuditEntry{Action: "install"})
+			}
+			close(recorded)
+		}()
+
+		Eventually(recorded).Should(BeClosed())
+	})
+
+	It("drops the entries, when the store is stalled and the buffer is full", func() {
+		kv.gate = make(chan struct{})
+
+		recorded := make(chan struct{})
+		go func() {
+			for i := 0; i < 1100; i++ {
+				log.Record(&provider.AuditEntry{Action: "install"})
+			}
+			close(recorded)
+		}()
+
+		Eventually(recorded).Should(BeClosed())
+
+		close(kv.gate)
+		Expect(log.Close()).To(Succeed())
+		Expect(kv.len()).To(BeNumerically("<=", 1001))
+	})
+
+	It("prunes the entries beyond the retention in the background", func() {
+		for i := 0; i < 100; i++ {
+			log.Record(&provider.AuditEntry{Action: "install", Time: time.Unix(int64(i), 0)})
+		}
+
+		Eventually(kv.len).Should(Equal(5))
+	})
+
+	It("writes the buffered entries on close, and drops the later ones", func() {
+		kv.gate = make(chan struct{})
+		for i := 0; i < 3; i++ {
+			log.Record(&provider.AuditEntry{Action: "install"})
+		}
+		close(kv.gate)
+
+		Expect(log.Close()).To(Succeed())
+		Expect(kv.len()).To(Equal(3))
+
+		log.Record(&provider.AuditEntry{Action: "install"})
+		Consistently(kv.len).Should(Equal(3))
+	})
+
+	It("mirrors the entries to the file", func() {
+		dir, err := ioutil.TempDir("", "audit")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(dir)
+
+		file := filepath.Join(dir, "audit.log")
+		mirrored, err := audit.New(&cfg.Config{Audit: cfg.Audit{File: file}}, &store{})
+		Expect(err).NotTo(HaveOccurred())
+
+		mirrored.Record(&provider.AuditEntry{Action: "install", Package: "nginx"})
+		Expect(mirrored.Close()).To(Succeed())
+
+		lines, err := ioutil.ReadFile(file)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(strings.Count(string(lines), "\n")).To(Equal(1))
+		Expect(string(lines)).To(ContainSubstring(`"nginx"`))
+	})
+})
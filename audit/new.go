@@ -0,0 +1,54 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"os"
+
+	"github.com/axelspringer/moppi/cfg"
+	"github.com/axelspringer/moppi/provider"
+)
+
+// New creates a new audit log in the store
+func New(config *cfg.Config, store Store) (*Log, error) {
+	return mustNew(&config.Audit, store)
+}
+
+// mustNew wraps the creation of a new audit log
+func mustNew(config *cfg.Audit, store Store) (*Log, error) {
+	log := &Log{
+		store:     store,
+		retention: config.Retention,
+		entries:   make(chan *provider.AuditEntry, buffer),
+		quit:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+
+	if log.retention <= 0 {
+		log.retention = defaultRetention
+	}
+
+	if config.File != "" {
+		file, err := os.OpenFile(config.File, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0640)
+		if err != nil {
+			return nil, err
+		}
+		log.file = file
+	}
+
+	go log.write()
+
+	return log, nil
+}
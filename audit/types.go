@@ -0,0 +1,55 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"github.com/axelspringer/moppi/provider"
+)
+
+// Store persists the audit log (e.g. the KV provider)
+type Store interface {
+	CreateAuditEntry(entry *provider.AuditEntry) error
+	GetAuditEntries() ([]*provider.AuditEntry, error)
+	DeleteAuditEntry(id string) error
+}
+
+// Log is an append-only audit log with a retention limit, the
+// entries are written and pruned in the background
+type Log struct {
+	store     Store
+	retention int
+	file      *os.File
+	records   int
+	entries   chan *provider.AuditEntry
+	quit      chan struct{}
+	done      chan struct{}
+	closed    bool
+	mu        sync.Mutex
+}
+
+// Filter filters the entries of the audit log
+type Filter struct {
+	Universe string
+	Identity string
+	Action   string
+	Result   string
+	Since    time.Time
+	Until    time.Time
+	Limit    int
+}
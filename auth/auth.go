@@ -0,0 +1,69 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Authenticate authenticates a request with the configured authenticators,
+// requests without credentials are given the anonymous roles
+func (a *Auth) Authenticate(req *http.Request) (*Identity, error) {
+	if !a.Enable {
+		return &Identity{Name: anonymous, Roles: Roles{AnyUniverse: {Admin}}, Anonymous: true}, nil
+	}
+
+	for _, authenticator := range a.authenticators {
+		identity, err := authenticator.Authenticate(req)
+		if err != nil {
+			return nil, err
+		}
+
+		if identity != nil {
+			return identity, nil
+		}
+	}
+
+	// credentials, that none of the authenticators understands
+	if req.Header.Get("Authorization") != "" {
+		return nil, ErrUnauthorized("unsupported authorization scheme")
+	}
+
+	return &Identity{Name: anonymous, Roles: a.anonymous, Anonymous: true}, nil
+}
+
+// Can returns if the identity has a role in a universe. Admins may do everything,
+// and everybody who can publish or install can also read.
+func (i *Identity) Can(universe string, role Role) bool {
+	for _, u := range []string{strings.ToLower(universe), AnyUniverse} {
+		for _, r := range i.Roles[u] {
+			if r == role || r == Admin || role == Read {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Authorize returns an error, if the identity does not have the role in a universe
+func (i *Identity) Authorize(universe string, role Role) error {
+	if i.Can(universe, role) {
+		return nil
+	}
+
+	return ErrForbidden{Identity: i.Name, Universe: universe, Role: role}
+}
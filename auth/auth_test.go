@@ -0,0 +1,141 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/axelspringer/moppi/auth"
+	"github.com/axelspringer/moppi/cfg"
+)
+
+var _ = Describe("Auth", func() {
+	var a *auth.Auth
+
+	BeforeEach(func() {
+		var err error
+		a, err = auth.New(&cfg.Config{Auth: cfg.Auth{
+			Enable:    true,
+			Tokens:    []cfg.Token{{Name: "ci", Token: "secret", Roles: cfg.Roles{"prod": {"install"}}}},
+			Anonymous: cfg.Roles{"Dev": {"Read"}},
+		}})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("gives everybody admin, when it is disabled", func() {
+		disabled, err := auth.New(&cfg.Config{})
+		Expect(err).NotTo(HaveOccurred())
+
+		identity, err := disabled.Authenticate(httptest.NewRequest(http.MethodGet, "/", nil))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(identity.Anonymous).To(BeTrue())
+		Expect(identity.Can("prod", auth.Admin)).To(BeTrue())
+	})
+
+	It("gives the anonymous roles to requests without credentials", func() {
+		identity, err := a.Authenticate(httptest.NewRequest(http.MethodGet, "/", nil))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(identity.Name).To(Equal("anonymous"))
+		Expect(identity.Anonymous).To(BeTrue())
+		Expect(identity.Can("dev", auth.Read)).To(BeTrue())
+		Expect(identity.Can("dev", auth.Install)).To(BeFalse())
+		Expect(identity.Can("prod", auth.Install)).To(BeFalse())
+	})
+
+	It("authenticates the first authenticator, that understands the credentials", func() {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer secret")
+
+		identity, err := a.Authenticate(req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(identity.Name).To(Equal("ci"))
+		Expect(identity.Anonymous).To(BeFalse())
+	})
+
+	It("rejects credentials, that no authenticator understands", func() {
+		for _, header := range []string{"Bearer unknown", "Digest username=ci"} {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Authorization", header)
+
+			_, err := a.Authenticate(req)
+			Expect(err).To(Equal(auth.ErrUnauthorized("unsupported authorization scheme")))
+		}
+	})
+})
+
+var _ = Describe("Identity", func() {
+	It("implies read from every role", func() {
+		for _, role := range []auth.Role{auth.Read, auth.Publish, auth.Install, auth.Admin} {
+			identity := &auth.Identity{Roles: auth.Roles{"prod": {role}}}
+			Expect(identity.Can("prod", auth.Read)).To(BeTrue(), string(role))
+		}
+	})
+
+	It("does not imply the other roles from publish or install", func() {
+		publisher := &auth.Identity{Roles: auth.Roles{"prod": {auth.Publish}}}
+		Expect(publisher.Can("prod", auth.Publish)).To(BeTrue())
+		Expect(publisher.Can("prod", auth.Install)).To(BeFalse())
+		Expect(publisher.Can("prod", auth.Admin)).To(BeFalse())
+
+		installer := &auth.Identity{Roles: auth.Roles{"prod": {auth.Install}}}
+		Expect(installer.Can("prod", auth.Install)).To(BeTrue())
+		Expect(installer.Can("prod", auth.Publish)).To(BeFalse())
+	})
+
+	It("implies every role from admin", func() {
+		identity := &auth.Identity{Roles: auth.Roles{"prod": {auth.Admin}}}
+
+		for _, role := range []auth.Role{auth.Read, auth.Publish, auth.Install, auth.Admin} {
+			Expect(identity.Can("prod", role)).To(BeTrue(), string(role))
+		}
+	})
+
+	It("only has its roles in its universes, matched case insensitive", func() {
+		identity := &auth.Identity{Roles: auth.Roles{"prod": {auth.Install}}}
+
+		Expect(identity.Can("PROD", auth.Install)).To(BeTrue())
+		Expect(identity.Can("dev", auth.Install)).To(BeFalse())
+		Expect(identity.Can("dev", auth.Read)).To(BeFalse())
+	})
+
+	It("has the roles of any universe in every universe", func() {
+		identity := &auth.Identity{Roles: auth.Roles{auth.AnyUniverse: {auth.Publish}}}
+
+		Expect(identity.Can("prod", auth.Publish)).To(BeTrue())
+		Expect(identity.Can("dev", auth.Publish)).To(BeTrue())
+		Expect(identity.Can("dev", auth.Install)).To(BeFalse())
+	})
+
+	It("has no roles, when it has none configured", func() {
+		identity := &auth.Identity{}
+
+		Expect(identity.Can("prod", auth.Read)).To(BeFalse())
+		Expect(identity.Authorize("prod", auth.Read)).To(Equal(auth.ErrForbidden{Universe: "prod", Role: auth.Read}))
+	})
+
+	It("returns who is not allowed to do what, where", func() {
+		identity := &auth.Identity{Name: "ci", Roles: auth.Roles{"prod": {auth.Read}}}
+
+		Expect(identity.Authorize("prod", auth.Read)).To(Succeed())
+
+		err := identity.Authorize("prod", auth.Install)
+		Expect(err).To(Equal(auth.ErrForbidden{Identity: "ci", Universe: "prod", Role: auth.Install}))
+		Expect(err).To(MatchError("ci is not allowed to install in universe prod"))
+	})
+})
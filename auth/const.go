@@ -0,0 +1,46 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import "time"
+
+const (
+	// Read allows to read universes and packages
+	Read Role = "read"
+	// Publish allows to publish and yank packages
+	Publish Role = "publish"
+	// Install allows to install and uninstall packages
+	Install Role = "install"
+	// Admin allows everything
+	Admin Role = "admin"
+)
+
+const (
+	// AnyUniverse matches every universe
+	AnyUniverse = "*"
+	// anonymous is the name of unauthenticated callers
+	anonymous = "anonymous"
+	// bearer is the scheme of bearer tokens
+	bearer = "Bearer "
+)
+
+const (
+	defaultUsernameClaim = "sub"
+	defaultGroupsClaim   = "groups"
+	// jwksRefresh limits how often an JWKS URL is refetched for unknown keys
+	jwksRefresh = time.Minute
+	// clockSkew is the leeway when validating the time claims
+	clockSkew = 30 * time.Second
+)
@@ -0,0 +1,37 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import "fmt"
+
+// ErrUnauthorized is returned when the passed credentials are invalid
+type ErrUnauthorized string
+
+// Error returns a custom error
+func (err ErrUnauthorized) Error() string {
+	return fmt.Sprintf("Invalid credentials: %v", string(err))
+}
+
+// ErrForbidden is returned when an identity lacks a role in a universe
+type ErrForbidden struct {
+	Identity string
+	Universe string
+	Role     Role
+}
+
+// Error returns a custom error
+func (err ErrForbidden) Error() string {
+	return fmt.Sprintf("%v is not allowed to %v in universe %v", err.Identity, err.Role, err.Universe)
+}
@@ -0,0 +1,98 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/axelspringer/moppi/cfg"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var _ Authenticator = (*Htpasswd)(nil)
+
+// NewHtpasswd creates an authenticator for basic auth from a htpasswd file,
+// supported are bcrypt and {SHA} hashes
+func NewHtpasswd(file string, users map[string]cfg.Roles) (*Htpasswd, error) {
+	h := &Htpasswd{
+		hashes: make(map[string]string),
+		users:  make(map[string]Roles),
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		h.hashes[parts[0]] = parts[1]
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for user, roles := range users {
+		h.users[user] = newRoles(roles)
+	}
+
+	return h, nil
+}
+
+// Authenticate authenticates basic auth credentials
+func (h *Htpasswd) Authenticate(req *http.Request) (*Identity, error) {
+	user, password, ok := req.BasicAuth()
+	if !ok {
+		return nil, nil
+	}
+
+	hash, ok := h.hashes[user]
+	if !ok || !compareHash(hash, password) {
+		return nil, ErrUnauthorized(user)
+	}
+
+	return &Identity{Name: user, Roles: h.users[user]}, nil
+}
+
+// compareHash compares a htpasswd hash with a password
+func compareHash(hash string, password string) bool {
+	switch {
+	case strings.HasPrefix(hash, "$2"):
+		return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+	case strings.HasPrefix(hash, "{SHA}"):
+		sum := sha1.Sum([]byte(password))
+		encoded := base64.StdEncoding.EncodeToString(sum[:])
+		return subtle.ConstantTimeCompare([]byte(strings.TrimPrefix(hash, "{SHA}")), []byte(encoded)) == 1
+	default:
+		return false
+	}
+}
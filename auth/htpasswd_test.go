@@ -0,0 +1,114 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/axelspringer/moppi/auth"
+	"github.com/axelspringer/moppi/cfg"
+)
+
+var _ = Describe("Htpasswd", func() {
+	var (
+		dir      string
+		htpasswd *auth.Htpasswd
+		request  func(user string, password string) *http.Request
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "htpasswd")
+		Expect(err).NotTo(HaveOccurred())
+
+		hash, err := bcrypt.GenerateFromPassword([]byte("bcrypt-password"), bcrypt.MinCost)
+		Expect(err).NotTo(HaveOccurred())
+
+		// {SHA} of "sha-password"
+		file := filepath.Join(dir, ".htpasswd")
+		lines := "# users of moppi\n\n" +
+			"alice:" + string(hash) + "\n" +
+			"bob:{SHA}MNLW6wfRtawHZ/atRhQOJCUt398=\n" +
+			"carol:$apr1$salt$hash\n" +
+			"broken\n"
+		Expect(ioutil.WriteFile(file, []byte(lines), 0600)).To(Succeed())
+
+		htpasswd, err = auth.NewHtpasswd(file, map[string]cfg.Roles{
+			"alice": {"prod": {"install"}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		request = func(user string, password string) *http.Request {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.SetBasicAuth(user, password)
+			return req
+		}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("authenticates a bcrypt password with the roles of the user", func() {
+		identity, err := htpasswd.Authenticate(request("alice", "bcrypt-password"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(identity.Name).To(Equal("alice"))
+		Expect(identity.Can("prod", auth.Install)).To(BeTrue())
+	})
+
+	It("authenticates a {SHA} password, a user without roles has none", func() {
+		identity, err := htpasswd.Authenticate(request("bob", "sha-password"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(identity.Name).To(Equal("bob"))
+		Expect(identity.Can("prod", auth.Read)).To(BeFalse())
+	})
+
+	It("rejects a wrong password", func() {
+		_, err := htpasswd.Authenticate(request("alice", "sha-password"))
+		Expect(err).To(Equal(auth.ErrUnauthorized("alice")))
+
+		_, err = htpasswd.Authenticate(request("bob", "bcrypt-password"))
+		Expect(err).To(Equal(auth.ErrUnauthorized("bob")))
+	})
+
+	It("rejects unknown users and unsupported hashes", func() {
+		for _, user := range []string{"mallory", "carol", "broken"} {
+			_, err := htpasswd.Authenticate(request(user, ""))
+			Expect(err).To(Equal(auth.ErrUnauthorized(user)))
+		}
+	})
+
+	It("leaves requests without basic auth to the other authenticators", func() {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer secret")
+
+		identity, err := htpasswd.Authenticate(req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(identity).To(BeNil())
+	})
+
+	It("fails on a missing file", func() {
+		_, err := auth.NewHtpasswd(filepath.Join(dir, "missing"), nil)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,206 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// NewJWKS loads the keys of a JWKS file or URL
+func NewJWKS(source string) (*JWKS, error) {
+	jwks := &JWKS{
+		source: source,
+		keys:   make(map[string]crypto.PublicKey),
+	}
+
+	if err := jwks.load(); err != nil {
+		return nil, err
+	}
+
+	return jwks, nil
+}
+
+// Key returns the public key with the key id, an unknown
+// key id triggers a refetch of remote a JWKS
+func (j *JWKS) Key(kid string) (crypto.PublicKey, error) {
+	j.mu.RLock()
+	key, ok := j.keys[kid]
+	refresh := j.remote() && time.Since(j.fetchedAt) > jwksRefresh
+	j.mu.RUnlock()
+
+	if ok {
+		return key, nil
+	}
+
+	if refresh {
+		if err := j.load(); err != nil {
+			return nil, err
+		}
+
+		j.mu.RLock()
+		key, ok = j.keys[kid]
+		j.mu.RUnlock()
+
+		if ok {
+			return key, nil
+		}
+	}
+
+	return nil, ErrUnauthorized(fmt.Sprintf("unknown key %q", kid))
+}
+
+// remote returns if the JWKS is fetched from an URL
+func (j *JWKS) remote() bool {
+	return strings.HasPrefix(j.source, "http://") || strings.HasPrefix(j.source, "https://")
+}
+
+// load reads and parses the JWKS document
+func (j *JWKS) load() error {
+	var data []byte
+	var err error
+
+	if j.remote() {
+		data, err = fetch(j.source)
+	} else {
+		data, err = ioutil.ReadFile(j.source)
+	}
+	if err != nil {
+		return err
+	}
+
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.keys = keys
+	j.fetchedAt = time.Now()
+
+	return nil
+}
+
+// fetch gets a JWKS document from an URL
+func fetch(url string) ([]byte, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	res, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Could not fetch JWKS from %s: %s", url, res.Status)
+	}
+
+	return ioutil.ReadAll(res.Body)
+}
+
+// parseJWKS parses the RSA and EC signing keys of a JWKS document
+func parseJWKS(data []byte) (map[string]crypto.PublicKey, error) {
+	var doc struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]crypto.PublicKey)
+	for _, jwk := range doc.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := jwk.publicKey()
+		if err != nil {
+			return nil, err
+		}
+
+		if key != nil {
+			keys[jwk.Kid] = key
+		}
+	}
+
+	return keys, nil
+}
+
+// publicKey converts a JWK to a public key, unsupported key types are skipped
+func (jwk *jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := decodeBigInt(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := decodeBigInt(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("Unsupported curve %s of key %s", jwk.Crv, jwk.Kid)
+		}
+
+		x, err := decodeBigInt(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := decodeBigInt(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// decodeBigInt decodes a base64url encoded big integer
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,243 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/axelspringer/moppi/cfg"
+)
+
+var _ Authenticator = (*JWT)(nil)
+
+// NewJWT creates an authenticator for JWTs signed by the keys of a JWKS
+func NewJWT(config *cfg.JWT) (*JWT, error) {
+	jwks, err := NewJWKS(config.JWKS)
+	if err != nil {
+		return nil, err
+	}
+
+	j := &JWT{
+		issuer:        config.Issuer,
+		audience:      config.Audience,
+		usernameClaim: config.UsernameClaim,
+		groupsClaim:   config.GroupsClaim,
+		groups:        make(map[string]Roles),
+		jwks:          jwks,
+	}
+
+	if j.usernameClaim == "" {
+		j.usernameClaim = defaultUsernameClaim
+	}
+
+	if j.groupsClaim == "" {
+		j.groupsClaim = defaultGroupsClaim
+	}
+
+	for group, roles := range config.Groups {
+		j.groups[group] = newRoles(roles)
+	}
+
+	return j, nil
+}
+
+// Authenticate authenticates a bearer JWT
+func (j *JWT) Authenticate(req *http.Request) (*Identity, error) {
+	header := req.Header.Get("Authorization")
+	if !strings.HasPrefix(header, bearer) {
+		return nil, nil
+	}
+
+	token := strings.TrimPrefix(header, bearer)
+	if strings.Count(token, ".") != 2 {
+		return nil, nil // not a JWT
+	}
+
+	claims, err := j.Validate(token, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return j.identity(claims)
+}
+
+// Validate verifies the signature of a token and validates
+// the issuer, audience and time claims
+func (j *JWT) Validate(token string, now time.Time) (map[string]interface{}, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrUnauthorized("malformed token")
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, ErrUnauthorized("malformed token header")
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrUnauthorized("malformed token signature")
+	}
+
+	key, err := j.jwks.Key(header.Kid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verifySignature(header.Alg, key, []byte(parts[0]+"."+parts[1]), sig); err != nil {
+		return nil, err
+	}
+
+	claims := make(map[string]interface{})
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, ErrUnauthorized("malformed token claims")
+	}
+
+	if err := j.validateClaims(claims, now); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+// validateClaims validates the registered claims
+func (j *JWT) validateClaims(claims map[string]interface{}, now time.Time) error {
+	if j.issuer != "" && claims["iss"] != j.issuer {
+		return ErrUnauthorized("invalid issuer")
+	}
+
+	if j.audience != "" && !containsClaim(claims["aud"], j.audience) {
+		return ErrUnauthorized("invalid audience")
+	}
+
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return ErrUnauthorized("missing expiry")
+	}
+
+	if now.Add(-clockSkew).After(time.Unix(int64(exp), 0)) {
+		return ErrUnauthorized("token expired")
+	}
+
+	if nbf, ok := claims["nbf"].(float64); ok && now.Add(clockSkew).Before(time.Unix(int64(nbf), 0)) {
+		return ErrUnauthorized("token not yet valid")
+	}
+
+	return nil
+}
+
+// identity maps the claims to an identity, the roles are merged from the groups,
+// a token without a username is rejected
+func (j *JWT) identity(claims map[string]interface{}) (*Identity, error) {
+	name, ok := claims[j.usernameClaim].(string)
+	if !ok || name == "" {
+		return nil, ErrUnauthorized(fmt.Sprintf("missing username claim %q", j.usernameClaim))
+	}
+
+	identity := &Identity{
+		Name:  name,
+		Roles: make(Roles),
+	}
+
+	groups, _ := claims[j.groupsClaim].([]interface{})
+	for _, group := range groups {
+		name, ok := group.(string)
+		if !ok {
+			continue
+		}
+
+		for universe, roles := range j.groups[name] {
+			identity.Roles[universe] = append(identity.Roles[universe], roles...)
+		}
+	}
+
+	return identity, nil
+}
+
+// verifySignature verifies the signature of the signing input with the key
+func verifySignature(alg string, key crypto.PublicKey, input []byte, sig []byte) error {
+	var hash crypto.Hash
+	switch alg {
+	case "RS256", "ES256":
+		hash = crypto.SHA256
+	case "RS384", "ES384":
+		hash = crypto.SHA384
+	case "RS512", "ES512":
+		hash = crypto.SHA512
+	default:
+		return ErrUnauthorized(fmt.Sprintf("unsupported algorithm %q", alg))
+	}
+
+	h := hash.New()
+	h.Write(input)
+	digest := h.Sum(nil)
+
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		if !strings.HasPrefix(alg, "RS") || rsa.VerifyPKCS1v15(key, hash, digest, sig) != nil {
+			return ErrUnauthorized("invalid signature")
+		}
+	case *ecdsa.PublicKey:
+		size := (key.Curve.Params().BitSize + 7) / 8
+		if !strings.HasPrefix(alg, "ES") || len(sig) != 2*size {
+			return ErrUnauthorized("invalid signature")
+		}
+
+		r := new(big.Int).SetBytes(sig[:size])
+		s := new(big.Int).SetBytes(sig[size:])
+		if !ecdsa.Verify(key, digest, r, s) {
+			return ErrUnauthorized("invalid signature")
+		}
+	default:
+		return ErrUnauthorized("unsupported key")
+	}
+
+	return nil
+}
+
+// decodeSegment decodes a base64url encoded JSON segment of a token
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// containsClaim returns if a string or list claim contains a value
+func containsClaim(claim interface{}, value string) bool {
+	switch claim := claim.(type) {
+	case string:
+		return claim == value
+	case []interface{}:
+		for _, v := range claim {
+			if v == value {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,157 @@
+package auth_test
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/axelspringer/moppi/auth"
+	"github.com/axelspringer/moppi/cfg"
+)
+
+var _ = Describe("JWT", func() {
+	var (
+		key  *rsa.PrivateKey
+		dir  string
+		jwt  *auth.JWT
+		b64  = base64.RawURLEncoding.EncodeToString
+		sign func(claims map[string]interface{}) string
+	)
+
+	BeforeEach(func() {
+		var err error
+		key, err = rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).NotTo(HaveOccurred())
+
+		// local JWKS file, no identity provider needed
+		jwks, _ := json.Marshal(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kid": "moppi",
+				"kty": "RSA",
+				"use": "sig",
+				"n":   b64(key.N.Bytes()),
+				"e":   b64(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+
+		dir, err = ioutil.TempDir("", "jwks")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ioutil.WriteFile(filepath.Join(dir, "jwks.json"), jwks, 0600)).To(Succeed())
+
+		jwt, err = auth.NewJWT(&cfg.JWT{
+			JWKS:     filepath.Join(dir, "jwks.json"),
+			Issuer:   "https://idp.example.com",
+			Audience: "moppi",
+			Groups: map[string]cfg.Roles{
+				"release": {"prod": {"publish"}},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		sign = func(claims map[string]interface{}) string {
+			header, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": "moppi"})
+			payload, _ := json.Marshal(claims)
+			input := b64(header) + "." + b64(payload)
+
+			sum := sha256.Sum256([]byte(input))
+			sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, sum[:])
+			Expect(err).NotTo(HaveOccurred())
+
+			return input + "." + b64(sig)
+		}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	authenticate := func(token string) (*auth.Identity, error) {
+		req, _ := http.NewRequest("GET", "/universes", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		return jwt.Authenticate(req)
+	}
+
+	It("maps the groups of a valid token to roles", func() {
+		identity, err := authenticate(sign(map[string]interface{}{
+			"sub":    "release-bot",
+			"iss":    "https://idp.example.com",
+			"aud":    []string{"moppi"},
+			"exp":    time.Now().Add(time.Hour).Unix(),
+			"groups": []string{"release"},
+		}))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(identity.Name).To(Equal("release-bot"))
+		Expect(identity.Can("prod", auth.Publish)).To(BeTrue())
+		Expect(identity.Can("prod", auth.Install)).To(BeFalse())
+		Expect(identity.Can("dev", auth.Read)).To(BeFalse())
+	})
+
+	It("rejects tokens without a username", func() {
+		_, err := authenticate(sign(map[string]interface{}{
+			"iss": "https://idp.example.com",
+			"aud": "moppi",
+			"exp": time.Now().Add(time.Hour).Unix(),
+		}))
+		Expect(err).To(Equal(auth.ErrUnauthorized(`missing username claim "sub"`)))
+
+		_, err = authenticate(sign(map[string]interface{}{
+			"sub": 42,
+			"iss": "https://idp.example.com",
+			"aud": "moppi",
+			"exp": time.Now().Add(time.Hour).Unix(),
+		}))
+		Expect(err).To(Equal(auth.ErrUnauthorized(`missing username claim "sub"`)))
+	})
+
+	It("rejects expired tokens", func() {
+		_, err := authenticate(sign(map[string]interface{}{
+			"sub": "release-bot",
+			"iss": "https://idp.example.com",
+			"aud": "moppi",
+			"exp": time.Now().Add(-time.Hour).Unix(),
+		}))
+
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("rejects tokens of other issuers and audiences", func() {
+		_, err := authenticate(sign(map[string]interface{}{
+			"iss": "https://evil.example.com",
+			"aud": "moppi",
+			"exp": time.Now().Add(time.Hour).Unix(),
+		}))
+		Expect(err).To(HaveOccurred())
+
+		_, err = authenticate(sign(map[string]interface{}{
+			"iss": "https://idp.example.com",
+			"aud": "other",
+			"exp": time.Now().Add(time.Hour).Unix(),
+		}))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("rejects tampered tokens", func() {
+		token := sign(map[string]interface{}{
+			"iss": "https://idp.example.com",
+			"aud": "moppi",
+			"exp": time.Now().Add(time.Hour).Unix(),
+		})
+
+		_, err := authenticate(token[:len(token)-4] + "AAAA")
+		Expect(err).To(HaveOccurred())
+	})
+})
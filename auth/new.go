@@ -0,0 +1,70 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"strings"
+
+	"github.com/axelspringer/moppi/cfg"
+)
+
+// New creates the authenticators from the config
+func New(config *cfg.Config) (*Auth, error) {
+	return mustNew(&config.Auth)
+}
+
+// mustNew wraps the creation of a new Auth
+func mustNew(config *cfg.Auth) (*Auth, error) {
+	auth := &Auth{
+		Enable:    config.Enable,
+		anonymous: newRoles(config.Anonymous),
+	}
+
+	if len(config.Tokens) > 0 {
+		auth.authenticators = append(auth.authenticators, NewTokens(config.Tokens))
+	}
+
+	if config.Htpasswd != "" {
+		htpasswd, err := NewHtpasswd(config.Htpasswd, config.Users)
+		if err != nil {
+			return nil, err
+		}
+		auth.authenticators = append(auth.authenticators, htpasswd)
+	}
+
+	if config.JWT != nil {
+		jwt, err := NewJWT(config.JWT)
+		if err != nil {
+			return nil, err
+		}
+		auth.authenticators = append(auth.authenticators, jwt)
+	}
+
+	return auth, nil
+}
+
+// newRoles converts configured roles
+func newRoles(config cfg.Roles) Roles {
+	roles := make(Roles)
+
+	for universe, names := range config {
+		universe = strings.ToLower(universe)
+		for _, name := range names {
+			roles[universe] = append(roles[universe], Role(strings.ToLower(name)))
+		}
+	}
+
+	return roles
+}
@@ -0,0 +1,58 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/axelspringer/moppi/cfg"
+)
+
+var _ Authenticator = (*Tokens)(nil)
+
+// NewTokens creates an authenticator for static bearer tokens
+func NewTokens(tokens []cfg.Token) *Tokens {
+	t := &Tokens{
+		tokens: make(map[string]*Identity),
+	}
+
+	for _, token := range tokens {
+		t.tokens[token.Token] = &Identity{
+			Name:  token.Name,
+			Roles: newRoles(token.Roles),
+		}
+	}
+
+	return t
+}
+
+// Authenticate authenticates a bearer token
+func (t *Tokens) Authenticate(req *http.Request) (*Identity, error) {
+	header := req.Header.Get("Authorization")
+	if !strings.HasPrefix(header, bearer) {
+		return nil, nil
+	}
+	token := strings.TrimPrefix(header, bearer)
+
+	for known, identity := range t.tokens {
+		if subtle.ConstantTimeCompare([]byte(known), []byte(token)) == 1 {
+			return identity, nil
+		}
+	}
+
+	return nil, nil // may be understood by other authenticators
+}
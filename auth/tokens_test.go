@@ -0,0 +1,68 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/axelspringer/moppi/auth"
+	"github.com/axelspringer/moppi/cfg"
+)
+
+var _ = Describe("Tokens", func() {
+	var (
+		tokens  *auth.Tokens
+		request func(header string) *http.Request
+	)
+
+	BeforeEach(func() {
+		tokens = auth.NewTokens([]cfg.Token{
+			{Name: "ci", Token: "secret", Roles: cfg.Roles{"Prod": {"Publish"}}},
+			{Name: "ops", Token: "other", Roles: cfg.Roles{"*": {"admin"}}},
+		})
+
+		request = func(header string) *http.Request {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if header != "" {
+				req.Header.Set("Authorization", header)
+			}
+			return req
+		}
+	})
+
+	It("authenticates a known token with its roles", func() {
+		identity, err := tokens.Authenticate(request("Bearer secret"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(identity.Name).To(Equal("ci"))
+		Expect(identity.Roles).To(Equal(auth.Roles{"prod": {auth.Publish}}))
+
+		identity, err = tokens.Authenticate(request("Bearer other"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(identity.Name).To(Equal("ops"))
+		Expect(identity.Can("dev", auth.Admin)).To(BeTrue())
+	})
+
+	It("leaves unknown tokens and other schemes to the other authenticators", func() {
+		for _, header := range []string{"", "Bearer unknown", "Bearer secre", "Bearer secrets", "bearer secret", "Basic c2VjcmV0"} {
+			identity, err := tokens.Authenticate(request(header))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(identity).To(BeNil(), header)
+		}
+	})
+})
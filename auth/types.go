@@ -0,0 +1,95 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"crypto"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Role describes what an identity is allowed to do in a universe
+type Role string
+
+// Roles maps universes to roles
+type Roles map[string][]Role
+
+// Identity describes an authenticated caller
+type Identity struct {
+	Name      string
+	Roles     Roles
+	Anonymous bool
+}
+
+// Authenticator authenticates a request. It returns a nil Identity,
+// if the request does not contain credentials it understands.
+type Authenticator interface {
+	Authenticate(req *http.Request) (*Identity, error)
+}
+
+// Auth holds the configured authenticators
+type Auth struct {
+	Enable         bool
+	authenticators []Authenticator
+	anonymous      Roles
+}
+
+// Tokens authenticates static bearer tokens
+type Tokens struct {
+	tokens map[string]*Identity
+}
+
+// Htpasswd authenticates basic auth against a htpasswd file
+type Htpasswd struct {
+	hashes map[string]string
+	users  map[string]Roles
+}
+
+// JWT authenticates JSON Web Tokens, that are issued by an identity provider
+type JWT struct {
+	issuer        string
+	audience      string
+	usernameClaim string
+	groupsClaim   string
+	groups        map[string]Roles
+	jwks          *JWKS
+}
+
+// JWKS holds the public keys of an identity provider by key id
+type JWKS struct {
+	source    string
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+	mu        sync.RWMutex
+}
+
+// jsonWebKey is a key of a JWKS document
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// jwtHeader is the header of a JWT
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
@@ -17,3 +17,10 @@ package cfg
 const (
 	defaultBucket = "moppi"
 )
+
+const (
+	// LogFormatText is the format of logs as text
+	LogFormatText = "text"
+	// LogFormatJSON is the format of logs as JSON
+	LogFormatJSON = "json"
+)
@@ -0,0 +1,25 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cfg
+
+import "fmt"
+
+// ErrLogFormat is returned for an unknown format of the logs
+type ErrLogFormat string
+
+// Error returns a custom error
+func (err ErrLogFormat) Error() string {
+	return fmt.Sprintf("Unknown log format %v, expected text or json", string(err))
+}
@@ -20,3 +20,26 @@ import (
 
 // Log exposes an instance of logrus logger
 var Log = log.New()
+
+// InitLog sets the level and the format of the logs
+func (c *Config) InitLog() error {
+	var formatter log.Formatter
+	switch c.LogFormat {
+	case "", LogFormatText:
+		formatter = &log.TextFormatter{}
+	case LogFormatJSON:
+		formatter = &log.JSONFormatter{}
+	default:
+		return ErrLogFormat(c.LogFormat)
+	}
+
+	level := log.InfoLevel // the standard log level is info
+	if c.Verbose {         // if verbose extend log level to debug
+		level = log.DebugLevel
+	}
+
+	Log.Formatter = formatter
+	Log.SetLevel(level)
+
+	return nil
+}
@@ -14,6 +14,8 @@
 
 package cfg
 
+import "github.com/spf13/viper"
+
 // New returns a new Config
 func New() (*Config, error) {
 	// config
@@ -22,11 +24,27 @@ func New() (*Config, error) {
 	return cfg, nil // noop
 }
 
-func (c *Config) Init() {
-	// initialize provider
+// Load reads the config file again, and decodes it into a new Config
+func Load() (*Config, error) {
+	if err := viper.ReadInConfig(); err != nil {
+		return nil, err
+	}
+
+	cfg, _ := New()
+	if err := viper.Unmarshal(cfg); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// Init initializes the KV provider
+func (c *Config) Init() error {
 	store, err := c.Etcd.CreateStore(defaultBucket)
 	if err != nil {
-		Log.Fatalf("Initializing provider: %v", err)
+		return err
 	}
 	c.Etcd.SetKVClient(store)
+
+	return nil
 }
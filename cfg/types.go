@@ -16,18 +16,92 @@ package cfg
 
 import (
 	"net"
+	"time"
 
 	"github.com/axelspringer/moppi/provider/etcd"
 )
 
 // Config holds the persistent config of Moppi
 type Config struct {
-	Chronos   string
-	Listen    string
-	Marathon  string
-	Mesos     string
-	Verbose   bool
-	Zookeeper string
-	Etcd      etcd.Provider
-	Listener  net.Listener
+	Chronos         string
+	Metronome       string
+	Listen          string
+	GRPCListen      string
+	Marathon        string
+	Mesos           string
+	Verbose         bool
+	LogFormat       string
+	ShutdownTimeout time.Duration
+	Workers         int
+	QueueCapacity   int
+	Zookeeper       string
+	Etcd            etcd.Provider
+	Listener        net.Listener
+	Signing         map[string]*Signing
+	Auth            Auth
+	Audit           Audit
+	Webhooks        Webhooks
+	Retry           Retry
+	TrustedProxies  []string
+}
+
+// Audit holds the configuration of the audit log, entries
+// are optionally mirrored as JSON lines to a file
+type Audit struct {
+	Retention int
+	File      string
+}
+
+// Retry holds the retry policy of the calls to Marathon and Chronos,
+// a transient failure is retried with an exponential backoff
+type Retry struct {
+	Attempts   int
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// Webhooks holds the configuration of the delivery of webhooks,
+// a failed delivery is retried with an exponential backoff
+type Webhooks struct {
+	Retries int
+	Timeout time.Duration
+}
+
+// Auth holds the configuration of the API authentication
+type Auth struct {
+	Enable    bool
+	Tokens    []Token
+	Htpasswd  string
+	Users     map[string]Roles
+	Anonymous Roles
+	JWT       *JWT
+}
+
+// JWT holds the configuration of JWT validation, the JWKS is
+// either a file or an URL of the identity provider
+type JWT struct {
+	JWKS          string
+	Issuer        string
+	Audience      string
+	UsernameClaim string
+	GroupsClaim   string
+	Groups        map[string]Roles
+}
+
+// Token is a static bearer token
+type Token struct {
+	Name  string
+	Token string
+	Roles Roles
+}
+
+// Roles maps universes to roles (read, publish, install, admin),
+// the universe * matches every universe
+type Roles map[string][]string
+
+// Signing holds the trusted publisher keys of a universe,
+// keys are either prefixed with ed25519: or pgp:
+type Signing struct {
+	Required bool
+	Keys     []string
 }
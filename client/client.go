@@ -0,0 +1,225 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/axelspringer/moppi/audit"
+	"github.com/axelspringer/moppi/provider"
+	"github.com/axelspringer/moppi/queue"
+)
+
+// Ping checks if the API is reachable
+func (c *Client) Ping(ctx context.Context) error {
+	return c.call(ctx, http.MethodGet, "/ping", nil, nil)
+}
+
+// Health checks the health of the API
+func (c *Client) Health(ctx context.Context) error {
+	return c.call(ctx, http.MethodGet, "/health", nil, nil)
+}
+
+// Version returns the version of the repository
+func (c *Client) Version(ctx context.Context) (string, error) {
+	var version []byte
+	err := c.call(ctx, http.MethodGet, "/version", nil, &version)
+
+	return string(version), err
+}
+
+// Install queues the installment of a package, the job is returned
+func (c *Client) Install(ctx context.Context, req *provider.Request) (*queue.Job, error) {
+	job := new(queue.Job)
+	if err := c.call(ctx, http.MethodPost, "/install", req, job); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// Uninstall queues the uninstallment of a package, the job is returned
+func (c *Client) Uninstall(ctx context.Context, req *provider.Request) (*queue.Job, error) {
+	job := new(queue.Job)
+	if err := c.call(ctx, http.MethodPost, "/uninstall", req, job); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// Jobs returns all the known jobs, the caller can read
+func (c *Client) Jobs(ctx context.Context) ([]queue.Job, error) {
+	var jobs []queue.Job
+	err := c.call(ctx, http.MethodGet, "/jobs", nil, &jobs)
+
+	return jobs, err
+}
+
+// Job returns a job
+func (c *Client) Job(ctx context.Context, id string) (*queue.Job, error) {
+	job := new(queue.Job)
+	if err := c.call(ctx, http.MethodGet, join("jobs", id), nil, job); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// Audit returns the entries of the audit log, newest first
+func (c *Client) Audit(ctx context.Context, filter *audit.Filter) ([]*provider.AuditEntry, error) {
+	query := url.Values{}
+	if filter != nil {
+		for key, value := range map[string]string{
+			"universe": filter.Universe,
+			"identity": filter.Identity,
+			"action":   filter.Action,
+			"result":   filter.Result,
+		} {
+			if value != "" {
+				query.Set(key, value)
+			}
+		}
+
+		if !filter.Since.IsZero() {
+			query.Set("since", filter.Since.Format(time.RFC3339))
+		}
+		if !filter.Until.IsZero() {
+			query.Set("until", filter.Until.Format(time.RFC3339))
+		}
+		if filter.Limit > 0 {
+			query.Set("limit", strconv.Itoa(filter.Limit))
+		}
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, "/audit", query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*provider.AuditEntry
+	err = c.do(req, &entries)
+
+	return entries, err
+}
+
+// Universes returns all the known universes, the caller can read
+func (c *Client) Universes(ctx context.Context) (provider.Universes, error) {
+	var universes provider.Universes
+	err := c.call(ctx, http.MethodGet, "/universes", nil, &universes)
+
+	return universes, err
+}
+
+// Universe returns a universe
+func (c *Client) Universe(ctx context.Context, universe string) (*provider.Universe, error) {
+	u := new(provider.Universe)
+	if err := c.call(ctx, http.MethodGet, join("universes", universe, "meta"), nil, u); err != nil {
+		return nil, err
+	}
+
+	return u, nil
+}
+
+// CreateUniverse creates a new universe
+func (c *Client) CreateUniverse(ctx context.Context, universe *provider.Universe) error {
+	return c.call(ctx, http.MethodPost, "/universes", universe, nil)
+}
+
+// DeleteUniverse deletes a universe
+func (c *Client) DeleteUniverse(ctx context.Context, universe string) error {
+	return c.call(ctx, http.MethodDelete, join("universes", universe), nil, nil)
+}
+
+// Packages returns all the packages in a universe
+func (c *Client) Packages(ctx context.Context, universe string) (provider.Packages, error) {
+	var pkgs provider.Packages
+	err := c.call(ctx, http.MethodGet, join("universes", universe, "packages"), nil, &pkgs)
+
+	return pkgs, err
+}
+
+// Revisions returns all the revisions of a package
+func (c *Client) Revisions(ctx context.Context, universe string, name string) (provider.PackageRevisions, error) {
+	var revs provider.PackageRevisions
+	err := c.call(ctx, http.MethodGet, join("universes", universe, "packages", name), nil, &revs)
+
+	return revs, err
+}
+
+// Package returns a revision of a package, the revision latest resolves
+// the latest revision, that is not yanked
+func (c *Client) Package(ctx context.Context, universe string, name string, revision string) (*provider.Package, error) {
+	pkg := new(provider.Package)
+	if err := c.call(ctx, http.MethodGet, join("universes", universe, "packages", name, revision), nil, pkg); err != nil {
+		return nil, err
+	}
+
+	return pkg, nil
+}
+
+// Revision returns the meta infos of a package revision
+func (c *Client) Revision(ctx context.Context, universe string, name string, revision string) (*provider.Revision, error) {
+	rev := new(provider.Revision)
+	if err := c.call(ctx, http.MethodGet, join("universes", universe, "packages", name, revision, "meta"), nil, rev); err != nil {
+		return nil, err
+	}
+
+	return rev, nil
+}
+
+// Publish creates a new revision of a package, the signature is optional
+func (c *Client) Publish(ctx context.Context, universe string, name string, pkg *provider.Package, signature string) (int, error) {
+	req, err := c.newRequest(ctx, http.MethodPost, join("universes", universe, "packages", name), nil, pkg)
+	if err != nil {
+		return 0, err
+	}
+
+	if signature != "" {
+		req.Header.Set(signatureHeader, signature)
+	}
+
+	var rev []byte
+	if err := c.do(req, &rev); err != nil {
+		return 0, err
+	}
+
+	return strconv.Atoi(strings.TrimSpace(string(rev)))
+}
+
+// YankPackage yanks all revisions of a package
+func (c *Client) YankPackage(ctx context.Context, universe string, name string) error {
+	return c.call(ctx, http.MethodDelete, join("universes", universe, "packages", name), nil, nil)
+}
+
+// YankRevision yanks a revision of a package
+func (c *Client) YankRevision(ctx context.Context, universe string, name string, revision string) error {
+	return c.call(ctx, http.MethodDelete, join("universes", universe, "packages", name, revision), nil, nil)
+}
+
+// PurgePackage removes a package with all its revisions, it requires the admin role
+func (c *Client) PurgePackage(ctx context.Context, universe string, name string) error {
+	return c.call(ctx, http.MethodDelete, join("admin", "universes", universe, "packages", name), nil, nil)
+}
+
+// PurgeRevision removes a revision of a package, it requires the admin role
+func (c *Client) PurgeRevision(ctx context.Context, universe string, name string, revision string) error {
+	return c.call(ctx, http.MethodDelete, join("admin", "universes", universe, "packages", name, revision), nil, nil)
+}
@@ -0,0 +1,176 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/axelspringer/moppi/api"
+	"github.com/axelspringer/moppi/client"
+	"github.com/axelspringer/moppi/provider"
+	"github.com/axelspringer/moppi/queue"
+)
+
+var _ = Describe("Client", func() {
+	var (
+		mux *http.ServeMux
+		ts  *httptest.Server
+		c   *client.Client
+	)
+
+	BeforeEach(func() {
+		var err error
+		mux = http.NewServeMux()
+		ts = httptest.NewServer(mux)
+
+		c, err = client.New(ts.URL, client.WithToken("s3cr3t"))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		ts.Close()
+	})
+
+	It("rejects an invalid endpoint", func() {
+		_, err := client.New("localhost")
+		Expect(err).To(BeAssignableToTypeOf(client.ErrEndpoint("")))
+	})
+
+	It("lists the universes with the auth header", func() {
+		mux.HandleFunc("/universes", func(w http.ResponseWriter, req *http.Request) {
+			Expect(req.Method).To(Equal(http.MethodGet))
+			Expect(req.Header.Get("Authorization")).To(Equal("Bearer s3cr3t"))
+			json.NewEncoder(w).Encode(provider.Universes{{Name: "Prod", Version: "1", Href: "/universes/prod"}})
+		})
+
+		universes, err := c.Universes(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(universes).To(HaveLen(1))
+		Expect(universes[0].Href).To(Equal("/universes/prod"))
+	})
+
+	It("publishes a signed package and returns the revision", func() {
+		mux.HandleFunc("/universes/prod/packages/nginx", func(w http.ResponseWriter, req *http.Request) {
+			Expect(req.Method).To(Equal(http.MethodPost))
+			Expect(req.Header.Get("X-Moppi-Signature")).To(Equal("ed25519:c2ln"))
+
+			var pkg provider.Package
+			Expect(json.NewDecoder(req.Body).Decode(&pkg)).To(Succeed())
+			Expect(pkg.Install.Marathon).To(BeTrue())
+
+			w.WriteHeader(http.StatusCreated)
+			io.WriteString(w, "3")
+		})
+
+		pkg := &provider.Package{Install: provider.Install{Marathon: true}}
+		rev, err := c.Publish(context.Background(), "prod", "nginx", pkg, "ed25519:c2ln")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rev).To(Equal(3))
+	})
+
+	It("installs a package and returns the job", func() {
+		mux.HandleFunc("/install", func(w http.ResponseWriter, req *http.Request) {
+			var pkgRequest provider.Request
+			Expect(json.NewDecoder(req.Body).Decode(&pkgRequest)).To(Succeed())
+
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(queue.Job{ID: "42", Kind: queue.KindInstall, Request: pkgRequest, State: queue.StateQueued})
+		})
+
+		job, err := c.Install(context.Background(), &provider.Request{Universe: "prod", Name: "nginx", Revision: "latest"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(job.ID).To(Equal("42"))
+		Expect(job.Request.Name).To(Equal("nginx"))
+	})
+
+	It("maps the error body of the API to an error", func() {
+		mux.HandleFunc("/universes/prod", func(w http.ResponseWriter, req *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(api.Error{Msg: "Not allowed", Err: "bot is not allowed to admin in universe prod"})
+		})
+
+		err := c.DeleteUniverse(context.Background(), "prod")
+		Expect(err).To(Equal(client.ErrResponse{
+			Status: http.StatusForbidden,
+			Msg:    "Not allowed",
+			Err:    "bot is not allowed to admin in universe prod",
+		}))
+	})
+
+	It("escapes the names in the path once", func() {
+		uris := make(chan string, 1)
+		mux.HandleFunc("/moppi/universes/", func(w http.ResponseWriter, req *http.Request) {
+			uris <- req.RequestURI
+			json.NewEncoder(w).Encode(provider.PackageRevisions{})
+		})
+
+		c, err := client.New(ts.URL+"/moppi/", client.WithToken("s3cr3t"))
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = c.Revisions(context.Background(), "prod", "tools/nginx 100%")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(<-uris).To(Equal("/moppi/universes/prod/packages/tools%2Fnginx%20100%25"))
+	})
+
+	It("returns the raw version", func() {
+		mux.HandleFunc("/version", func(w http.ResponseWriter, req *http.Request) {
+			io.WriteString(w, "0.1.0")
+		})
+
+		version, err := c.Version(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(version).To(Equal("0.1.0"))
+	})
+
+	It("cancels a request with its context", func() {
+		done := make(chan struct{})
+		defer close(done)
+		mux.HandleFunc("/jobs", func(w http.ResponseWriter, req *http.Request) {
+			select {
+			case <-done:
+			case <-req.Context().Done():
+			}
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err := c.Jobs(ctx)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("times out a request", func() {
+		done := make(chan struct{})
+		defer close(done)
+		mux.HandleFunc("/ping", func(w http.ResponseWriter, req *http.Request) {
+			select {
+			case <-done:
+			case <-req.Context().Done():
+			}
+		})
+
+		c, err := client.New(ts.URL, client.WithTimeout(50*time.Millisecond))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.Ping(context.Background())).NotTo(Succeed())
+	})
+})
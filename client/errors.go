@@ -0,0 +1,38 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import "fmt"
+
+// ErrResponse is returned when the API responds with an error status,
+// it carries the decoded error body of the API
+type ErrResponse struct {
+	Status int
+	Msg    string
+	Err    string
+}
+
+// Error returns a custom error
+func (err ErrResponse) Error() string {
+	return fmt.Sprintf("%v (%d): %v", err.Msg, err.Status, err.Err)
+}
+
+// ErrEndpoint is returned when the endpoint of the API is not a valid URL
+type ErrEndpoint string
+
+// Error returns a custom error
+func (err ErrEndpoint) Error() string {
+	return fmt.Sprintf("Invalid endpoint: %v", string(err))
+}
@@ -0,0 +1,126 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/axelspringer/moppi/api"
+)
+
+// newRequest creates a request of the API for an escaped path, the body is encoded as JSON
+func (c *Client) newRequest(ctx context.Context, method string, path string, query url.Values, body interface{}) (*http.Request, error) {
+	var r io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		r = bytes.NewReader(data)
+	}
+
+	// the path is escaped already, an escaped slash is kept in the raw path
+	u := *c.endpoint
+	u.RawPath = strings.TrimSuffix(u.EscapedPath(), "/") + path
+	unescaped, err := url.PathUnescape(u.RawPath)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = unescaped
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequest(method, u.String(), r)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	req.Header.Set("Accept", contentType)
+	req.Header.Set("User-Agent", userAgent)
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.authorization != "" {
+		req.Header.Set("Authorization", c.authorization)
+	}
+
+	return req, nil
+}
+
+// do sends a request and decodes the JSON response into out,
+// the raw response is kept for a *[]byte
+func (c *Client) do(req *http.Request, out interface{}) error {
+	res, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode >= http.StatusBadRequest {
+		return newErrResponse(res.StatusCode, body)
+	}
+
+	switch out := out.(type) {
+	case nil:
+		return nil
+	case *[]byte:
+		*out = body
+		return nil
+	}
+
+	return json.Unmarshal(body, out)
+}
+
+// call creates and sends a request of the API
+func (c *Client) call(ctx context.Context, method string, path string, body interface{}, out interface{}) error {
+	req, err := c.newRequest(ctx, method, path, nil, body)
+	if err != nil {
+		return err
+	}
+
+	return c.do(req, out)
+}
+
+// newErrResponse maps the error body of the API to an error
+func newErrResponse(status int, body []byte) error {
+	var apiErr api.Error
+	if err := json.Unmarshal(body, &apiErr); err != nil || apiErr.Msg == "" {
+		return ErrResponse{Status: status, Msg: http.StatusText(status), Err: strings.TrimSpace(string(body))}
+	}
+
+	return ErrResponse{Status: status, Msg: apiErr.Msg, Err: apiErr.Err}
+}
+
+// join joins the escaped segments of a path
+func join(segments ...string) string {
+	var path string
+	for _, segment := range segments {
+		path += "/" + url.PathEscape(segment)
+	}
+
+	return path
+}
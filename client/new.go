@@ -0,0 +1,49 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// New returns a new Client of the API at the endpoint (e.g. http://localhost:8080)
+func New(endpoint string, opts ...Opt) (*Client, error) {
+	return mustNew(endpoint, opts...)
+}
+
+// mustNew wraps the creation of a new Client
+func mustNew(endpoint string, opts ...Opt) (*Client, error) {
+	u, err := url.Parse(strings.TrimSuffix(endpoint, "/"))
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme == "" || u.Host == "" {
+		return nil, ErrEndpoint(endpoint)
+	}
+
+	client := &Client{
+		endpoint: u,
+		http:     &http.Client{Timeout: defaultTimeout},
+	}
+
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	return client, nil
+}
@@ -0,0 +1,51 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"encoding/base64"
+	"net/http"
+	"time"
+)
+
+// WithToken authenticates every request with a bearer token (static token or JWT)
+func WithToken(token string) Opt {
+	return func(c *Client) {
+		c.authorization = "Bearer " + token
+	}
+}
+
+// WithBasicAuth authenticates every request with basic auth
+func WithBasicAuth(user, password string) Opt {
+	return func(c *Client) {
+		c.authorization = "Basic " + base64.StdEncoding.EncodeToString([]byte(user+":"+password))
+	}
+}
+
+// WithHTTPClient uses a custom http client (e.g. for TLS)
+func WithHTTPClient(client *http.Client) Opt {
+	return func(c *Client) {
+		c.http = client
+	}
+}
+
+// WithTimeout limits the time of every request, zero means no timeout
+func WithTimeout(timeout time.Duration) Opt {
+	return func(c *Client) {
+		http := *c.http
+		http.Timeout = timeout
+		c.http = &http
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/axelspringer/moppi/client"
+	"github.com/axelspringer/moppi/provider"
+	"github.com/axelspringer/moppi/queue"
+	"github.com/spf13/viper"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// newClient returns a client of the moppi server
+func newClient() (*client.Client, error) {
+	var opts []client.Opt
+	if token := viper.GetString("token"); token != "" {
+		opts = append(opts, client.WithToken(token))
+	}
+
+	return client.New(viper.GetString("server"), opts...)
+}
+
+// parseRef parses a reference to a package <universe>/<name>[@revision]
+func parseRef(ref string) (*provider.Request, error) {
+	req := &provider.Request{}
+
+	parts := strings.SplitN(ref, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, ErrInvalidRef(ref)
+	}
+	req.Universe, req.Name = parts[0], parts[1]
+
+	if i := strings.LastIndex(req.Name, revisionPrefix); i >= 0 {
+		req.Name, req.Revision = req.Name[:i], req.Name[i+1:]
+		if req.Name == "" || req.Revision == "" {
+			return nil, ErrInvalidRef(ref)
+		}
+	}
+
+	return req, nil
+}
+
+// render writes v in the output format, table writes the rows of the table
+func render(v interface{}, table func(w io.Writer)) error {
+	switch output := viper.GetString("output"); output {
+	case outputJSON:
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case outputYAML:
+		// keep the names of the JSON documents
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+
+		var doc interface{}
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return err
+		}
+
+		data, err = yaml.Marshal(doc)
+		if err != nil {
+			return err
+		}
+
+		_, err = os.Stdout.Write(data)
+		return err
+	case outputTable:
+		w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+		table(w)
+		return w.Flush()
+	default:
+		return ErrOutput(output)
+	}
+}
+
+// writeJobs writes jobs as rows of a table
+func writeJobs(w io.Writer, jobs ...queue.Job) {
+	fmt.Fprintln(w, "ID\tKIND\tPACKAGE\tSTATE\tCREATED\tERROR")
+	for _, job := range jobs {
+		ref := job.Request.Universe + "/" + job.Request.Name
+		if job.Request.Revision != "" {
+			ref += revisionPrefix + job.Request.Revision
+		}
+
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\n", job.ID, job.Kind, ref, job.State, job.Created.Format(time.RFC3339), job.Error)
+	}
+}
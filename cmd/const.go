@@ -14,6 +14,8 @@
 
 package cmd
 
+import "github.com/axelspringer/moppi/cfg"
+
 const (
 	versionShort = "Print the version number of Moppi"
 	versionLong  = `All software has versions. This is Moppis`
@@ -22,3 +24,18 @@ const (
 	setupLong    = ``
 	setupCmd     = "setup"
 )
+
+const (
+	defaultServer  = "http://localhost:8080"
+	defaultOutput  = outputTable
+	outputJSON     = "json"
+	outputYAML     = "yaml"
+	outputTable    = "table"
+	serverEnv      = "MOPPI_SERVER"
+	tokenEnv       = "MOPPI_TOKEN"
+	revisionPrefix = "@"
+)
+
+const (
+	defaultLogFormat = cfg.LogFormatText
+)
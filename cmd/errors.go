@@ -0,0 +1,41 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import "fmt"
+
+// ErrInvalidRef is returned when a package is not referenced as <universe>/<name>[@revision]
+type ErrInvalidRef string
+
+// Error returns a custom error
+func (err ErrInvalidRef) Error() string {
+	return fmt.Sprintf("Invalid package %v, expected <universe>/<name>[@revision]", string(err))
+}
+
+// ErrOutput is returned for an unknown output format
+type ErrOutput string
+
+// Error returns a custom error
+func (err ErrOutput) Error() string {
+	return fmt.Sprintf("Unknown output %v, expected json, yaml or table", string(err))
+}
+
+// ErrMissingKey is returned when a package should be signed without a key
+type ErrMissingKey string
+
+// Error returns a custom error
+func (err ErrMissingKey) Error() string {
+	return fmt.Sprintf("A private key is required to sign, pass it with %v", string(err))
+}
@@ -19,12 +19,12 @@ import (
 	"os/signal"
 	"syscall"
 
-	"github.com/axelspringer/pitti/cfg"
+	"github.com/axelspringer/moppi/cfg"
 )
 
-// gracefulShutdown handles a graceful shutdown
+// waitGracefulShutdown handles a graceful shutdown
 func waitGracefulShutdown() {
-	cfg.Log.Infof("Graceful shutdown")
+	cfg.Log.Info("Graceful shutdown")
 
 	close(exit) // close all routines
 
@@ -32,24 +32,16 @@ func waitGracefulShutdown() {
 	close(shutdown)
 }
 
-// watchdog is watching important syscalls
+// watchdog is watching important syscalls, and shuts down on SIGINT and SIGTERM
 func watchdog() {
 	sys := make(chan os.Signal, 1) // create new channel for syscalls
-	signal.Notify(sys, syscall.SIGINT, syscall.SIGTERM, syscall.SIGUSR1)
+	signal.Notify(sys, syscall.SIGINT, syscall.SIGTERM)
 
-	defer close(sys)
+	defer signal.Stop(sys)
 	defer waitGracefulShutdown()
 
-	for {
-		select {
-		case <-exit: // wait for signal on exit channel
-			return
-		case sig := <-sys:
-			switch sig {
-			case syscall.SIGUSR1, syscall.SIGINT:
-				return
-			default:
-			}
-		}
+	select {
+	case <-exit: // wait for signal on exit channel
+	case <-sys:
 	}
 }
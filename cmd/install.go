@@ -0,0 +1,80 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"io"
+
+	"github.com/axelspringer/moppi/queue"
+	"github.com/spf13/cobra"
+)
+
+// newInstallCmd returns the install command of the client
+func newInstallCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "install <universe>/<name>[@revision]",
+		Short: "Install a package, the latest revision by default",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runInstallE,
+	}
+}
+
+// newUninstallCmd returns the uninstall command of the client
+func newUninstallCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "uninstall <universe>/<name>[@revision]",
+		Short: "Uninstall a package, the latest revision by default",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runUninstallE,
+	}
+}
+
+// runInstallE queues the installment of a package
+func runInstallE(c *cobra.Command, args []string) error {
+	return enqueue(args[0], queue.KindInstall)
+}
+
+// runUninstallE queues the uninstallment of a package
+func runUninstallE(c *cobra.Command, args []string) error {
+	return enqueue(args[0], queue.KindUninstall)
+}
+
+// enqueue queues an installment or uninstallment, and renders its job
+func enqueue(ref string, kind string) error {
+	req, err := parseRef(ref)
+	if err != nil {
+		return err
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	send := client.Install
+	if kind == queue.KindUninstall {
+		send = client.Uninstall
+	}
+
+	job, err := send(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	return render(job, func(w io.Writer) {
+		writeJobs(w, *job)
+	})
+}
@@ -0,0 +1,60 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"io"
+
+	"github.com/spf13/cobra"
+)
+
+// newJobsCmd returns the jobs command of the client
+func newJobsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "jobs [id]",
+		Short: "List the installments and uninstallments, or get one of them",
+		Args:  cobra.MaximumNArgs(1),
+		RunE:  runJobsE,
+	}
+}
+
+// runJobsE lists the jobs or gets a job
+func runJobsE(c *cobra.Command, args []string) error {
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 1 {
+		job, err := client.Job(context.Background(), args[0])
+		if err != nil {
+			return err
+		}
+
+		return render(job, func(w io.Writer) {
+			writeJobs(w, *job)
+		})
+	}
+
+	jobs, err := client.Jobs(context.Background())
+	if err != nil {
+		return err
+	}
+
+	return render(jobs, func(w io.Writer) {
+		writeJobs(w, jobs...)
+	})
+}
@@ -0,0 +1,317 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/axelspringer/moppi/provider"
+	"github.com/axelspringer/moppi/signing"
+	"github.com/spf13/cobra"
+)
+
+// newPackageCmd returns the package command of the client
+func newPackageCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "package",
+		Short: "Manage the packages of a universe",
+	}
+
+	publish := &cobra.Command{
+		Use:   "publish <universe>/<name>",
+		Short: "Publish a new revision of a package",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runPackagePublishE,
+	}
+	publish.Flags().StringP("file", "f", "-", "Package document, - reads stdin")
+	publish.Flags().String("signature", "", "Detached signature of the package (ed25519:<base64> or pgp:<base64>)")
+
+	sign := &cobra.Command{
+		Use:   "sign",
+		Short: "Sign a package document with a private key, and print the signature to publish it with",
+		Args:  cobra.NoArgs,
+		RunE:  runPackageSignE,
+	}
+	sign.Flags().StringP("file", "f", "-", "Package document, - reads stdin")
+	sign.Flags().StringP("key", "k", "", "File of the private key, ed25519:<base64 seed> or an armored PGP key")
+
+	digest := &cobra.Command{
+		Use:   "digest",
+		Short: "Print the digest of the canonical document of a package",
+		Args:  cobra.NoArgs,
+		RunE:  runPackageDigestE,
+	}
+	digest.Flags().StringP("file", "f", "-", "Package document, - reads stdin")
+
+	del := &cobra.Command{
+		Use:   "delete <universe>/<name>[@revision]",
+		Short: "Yank a package or a revision of it",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runPackageDeleteE,
+	}
+	del.Flags().Bool("purge", false, "Remove the package or revision, instead of yanking it")
+
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "list <universe>",
+			Short: "List the packages of a universe",
+			Args:  cobra.ExactArgs(1),
+			RunE:  runPackageListE,
+		},
+		&cobra.Command{
+			Use:   "revisions <universe>/<name>",
+			Short: "List the revisions of a package",
+			Args:  cobra.ExactArgs(1),
+			RunE:  runPackageRevisionsE,
+		},
+		&cobra.Command{
+			Use:   "show <universe>/<name>[@revision]",
+			Short: "Show a revision of a package, the latest by default",
+			Args:  cobra.ExactArgs(1),
+			RunE:  runPackageShowE,
+		},
+		publish,
+		sign,
+		digest,
+		del,
+	)
+
+	return cmd
+}
+
+// runPackageListE lists the packages of a universe
+func runPackageListE(c *cobra.Command, args []string) error {
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	pkgs, err := client.Packages(context.Background(), args[0])
+	if err != nil {
+		return err
+	}
+
+	return render(pkgs, func(w io.Writer) {
+		fmt.Fprintln(w, "NAME")
+		for _, pkg := range pkgs {
+			fmt.Fprintln(w, pkg)
+		}
+	})
+}
+
+// runPackageRevisionsE lists the revisions of a package
+func runPackageRevisionsE(c *cobra.Command, args []string) error {
+	req, err := parseRef(args[0])
+	if err != nil {
+		return err
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	revs, err := client.Revisions(context.Background(), req.Universe, req.Name)
+	if err != nil {
+		return err
+	}
+
+	return render(revs, func(w io.Writer) {
+		fmt.Fprintln(w, "REVISION")
+		for _, rev := range revs {
+			fmt.Fprintln(w, rev)
+		}
+	})
+}
+
+// runPackageShowE shows a revision of a package
+func runPackageShowE(c *cobra.Command, args []string) error {
+	req, err := parseRef(args[0])
+	if err != nil {
+		return err
+	}
+
+	if req.Revision == "" {
+		req.Revision = provider.RevisionLatest
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	pkg, err := client.Package(context.Background(), req.Universe, req.Name, req.Revision)
+	if err != nil {
+		return err
+	}
+
+	return render(pkg, func(w io.Writer) {
+		fmt.Fprintln(w, "KIND\tNAME")
+		for _, app := range pkg.Marathon {
+			fmt.Fprintf(w, "marathon\t%v\n", app.ID)
+		}
+		for _, pod := range pkg.Pods {
+			fmt.Fprintf(w, "pod\t%v\n", pod.ID)
+		}
+		for _, group := range pkg.Groups {
+			fmt.Fprintf(w, "group\t%v\n", group.ID)
+		}
+		for _, job := range pkg.Chronos {
+			fmt.Fprintf(w, "chronos\t%v\n", job.Name)
+		}
+		for _, job := range pkg.Metronome {
+			fmt.Fprintf(w, "metronome\t%v\n", job.ID)
+		}
+		for _, framework := range pkg.Install.Frameworks {
+			fmt.Fprintf(w, "framework\t%v\n", framework.Name)
+		}
+	})
+}
+
+// runPackagePublishE publishes a new revision of a package
+func runPackagePublishE(c *cobra.Command, args []string) error {
+	req, err := parseRef(args[0])
+	if err != nil {
+		return err
+	}
+
+	file, _ := c.Flags().GetString("file")
+	signature, _ := c.Flags().GetString("signature")
+
+	pkg, err := readPackage(file)
+	if err != nil {
+		return err
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	rev, err := client.Publish(context.Background(), req.Universe, req.Name, pkg, signature)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(rev)
+
+	return nil
+}
+
+// runPackageSignE signs a package document, the signature covers its canonical document
+func runPackageSignE(c *cobra.Command, args []string) error {
+	file, _ := c.Flags().GetString("file")
+	keyFile, _ := c.Flags().GetString("key")
+	if keyFile == "" {
+		return ErrMissingKey("--key")
+	}
+
+	pkg, err := readPackage(file)
+	if err != nil {
+		return err
+	}
+
+	key, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return err
+	}
+
+	// an armored PGP key is taken as it is
+	if strings.HasPrefix(strings.TrimSpace(string(key)), "-----BEGIN PGP") {
+		key = append([]byte(signing.PGP+":"), key...)
+	}
+
+	signature, err := signing.Sign(pkg, string(key))
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(signature)
+
+	return nil
+}
+
+// runPackageDigestE prints the digest of a package document
+func runPackageDigestE(c *cobra.Command, args []string) error {
+	file, _ := c.Flags().GetString("file")
+
+	pkg, err := readPackage(file)
+	if err != nil {
+		return err
+	}
+
+	digest, err := provider.Digest(pkg)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(digest)
+
+	return nil
+}
+
+// readPackage reads a package document from a file, - reads stdin
+func readPackage(file string) (*provider.Package, error) {
+	var data []byte
+	var err error
+	if file == "-" {
+		data, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		data, err = ioutil.ReadFile(file)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var pkg provider.Package
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return nil, err
+	}
+
+	return &pkg, nil
+}
+
+// runPackageDeleteE yanks or purges a package or a revision of it
+func runPackageDeleteE(c *cobra.Command, args []string) error {
+	req, err := parseRef(args[0])
+	if err != nil {
+		return err
+	}
+
+	purge, _ := c.Flags().GetBool("purge")
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	switch {
+	case purge && req.Revision == "":
+		return client.PurgePackage(ctx, req.Universe, req.Name)
+	case purge:
+		return client.PurgeRevision(ctx, req.Universe, req.Name, req.Revision)
+	case req.Revision == "":
+		return client.YankPackage(ctx, req.Universe, req.Name)
+	default:
+		return client.YankRevision(ctx, req.Universe, req.Name, req.Revision)
+	}
+}
@@ -18,19 +18,23 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/axelspringer/moppi/cfg"
 	"github.com/axelspringer/moppi/server"
 	"github.com/spf13/pflag"
 
-	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
 const (
-	defaultListener = "localhost:8080"
-	defaultVerbose  = false
+	defaultListener        = "localhost:8080"
+	defaultGRPCListener    = "localhost:8081"
+	defaultVerbose         = false
+	defaultShutdownTimeout = 30 * time.Second
+	defaultWorkers         = 1
+	defaultQueueCapacity   = 100
 )
 
 var (
@@ -79,16 +83,27 @@ func init() {
 
 	// Enables verbose output
 	RootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", defaultVerbose, "verbose output")
+	RootCmd.PersistentFlags().String("logformat", defaultLogFormat, "Format of the logs: text or json")
 
 	// Bind to
 	RootCmd.PersistentFlags().StringVarP(&listener, "listen", "", defaultListener, "Bind listener to (Default: localhost:8080)")
+	RootCmd.PersistentFlags().String("grpclisten", defaultGRPCListener, "Bind gRPC listener to (Default: localhost:8081)")
+	RootCmd.PersistentFlags().Int("workers", defaultWorkers, "Number of workers, that install the packages (Default: 1)")
+	RootCmd.PersistentFlags().Int("queuecapacity", defaultQueueCapacity, "Number of jobs, that can wait for a worker (Default: 100)")
+	RootCmd.PersistentFlags().Duration("shutdowntimeout", defaultShutdownTimeout, "Time to wait for requests and running jobs on a shutdown (Default: 30s)")
 
 	// Some more specific flags
 	RootCmd.PersistentFlags().String("chronos", "", "Chronos endpoints")
+	RootCmd.PersistentFlags().String("metronome", "", "Metronome endpoint")
 	RootCmd.PersistentFlags().String("marathon", "", "Marathon Endpoint")
 	RootCmd.PersistentFlags().String("mesos", "", "Mesos Endpoint")
 	RootCmd.PersistentFlags().String("zookeeper", "", "List of Zookeepers")
 
+	// Talk to a running moppi
+	RootCmd.PersistentFlags().String("server", defaultServer, "Moppi server of the client commands (Env: MOPPI_SERVER)")
+	RootCmd.PersistentFlags().String("token", "", "Bearer token of the client commands (Env: MOPPI_TOKEN)")
+	RootCmd.PersistentFlags().StringP("output", "o", defaultOutput, "Output of the client commands: json, yaml or table")
+
 	// Add all commands
 	addCommands(RootCmd)
 }
@@ -100,6 +115,13 @@ func addCommands(cmd *cobra.Command) {
 
 	// adding setup command
 	cmd.AddCommand(newSetupCmd())
+
+	// adding client commands
+	cmd.AddCommand(newUniverseCmd())
+	cmd.AddCommand(newPackageCmd())
+	cmd.AddCommand(newInstallCmd())
+	cmd.AddCommand(newUninstallCmd())
+	cmd.AddCommand(newJobsCmd())
 }
 
 // initConfig reads in config file and ENV variables if set.
@@ -133,11 +155,13 @@ func initConfig() {
 	}
 
 	viper.AutomaticEnv() // read in environment variables that match
+	viper.BindEnv("server", serverEnv)
+	viper.BindEnv("token", tokenEnv)
 
 	// If a config file is found, read it in.
 	err = viper.ReadInConfig()
 	if err == nil && verbose {
-		cfg.Log.Infof("Using config file:", viper.ConfigFileUsed())
+		cfg.Log.Infof("Using config file: %v", viper.ConfigFileUsed())
 	}
 
 	// Decode command config
@@ -146,32 +170,35 @@ func initConfig() {
 		cfg.Log.WithError(err).Fatal("Unable to decode into config")
 	}
 
-	cfg.Log.SetLevel(log.InfoLevel) // the standard log level is info
-	if config.Verbose {             // if verbose extend log level to debug
-		cfg.Log.SetLevel(log.DebugLevel)
+	if err := config.InitLog(); err != nil {
+		cfg.Log.WithError(err).Fatal("Unable to configure the logs")
 	}
 
-	// init provider
-	if err = config.Init(); err != nil {
+	// only log, when verbose is enabled
+	cfg.Log.Debug("Configuration initialized")
+}
+
+// initProvider initializes the KV provider, the client commands do not need it
+func initProvider() {
+	if err := config.Init(); err != nil {
 		cfg.Log.WithError(err).Fatal("Unable to init KV provider")
 	}
-
-	// only log, when verbose is enabled
-	cfg.Log.Info("Configuration initialized")
 }
 
 // run is running a server and is passing along the config
 func run(cmd *cobra.Command, args []string) {
 	var err error // handle error
 
+	// init provider
+	initProvider()
+
 	// watch relevant syscalls
 	go watchdog()
 
 	// create server
 	server, err := server.New(config, exit, &wg)
 	if err != nil {
-		cfg.Log.Errorf("Failed to create server: %v", err)
-		waitGracefulShutdown()
+		cfg.Log.WithError(err).Fatal("Failed to create server")
 	}
 
 	// start server
@@ -29,11 +29,13 @@ func newSetupCmd() *cobra.Command {
 }
 
 // runSetupE contains the main functionality to setup Moppi
-// in supported KVs (kvlib)
+// in supported KVs (kvlib), and migrates the revisions of an existing setup
 func runSetupE(c *cobra.Command, args []string) error {
+	initProvider()
+
 	if ok, err := config.Etcd.Setup(); !ok {
 		return err
 	}
 
-	return nil // noop
+	return config.Etcd.MigrateDigests()
 }
@@ -0,0 +1,132 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+
+	"github.com/axelspringer/moppi/provider"
+	"github.com/spf13/cobra"
+)
+
+// newUniverseCmd returns the universe command of the client
+func newUniverseCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "universe",
+		Short: "Manage the universes of a moppi server",
+	}
+
+	create := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create a universe",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runUniverseCreateE,
+	}
+	create.Flags().String("description", "", "Description of the universe")
+	create.Flags().String("version", "1", "Version of the universe")
+
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "list",
+			Short: "List the universes",
+			Args:  cobra.NoArgs,
+			RunE:  runUniverseListE,
+		},
+		&cobra.Command{
+			Use:   "get <universe>",
+			Short: "Get a universe",
+			Args:  cobra.ExactArgs(1),
+			RunE:  runUniverseGetE,
+		},
+		create,
+		&cobra.Command{
+			Use:   "delete <universe>",
+			Short: "Delete a universe",
+			Args:  cobra.ExactArgs(1),
+			RunE:  runUniverseDeleteE,
+		},
+	)
+
+	return cmd
+}
+
+// runUniverseListE lists the universes
+func runUniverseListE(c *cobra.Command, args []string) error {
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	universes, err := client.Universes(context.Background())
+	if err != nil {
+		return err
+	}
+
+	return render(universes, func(w io.Writer) {
+		writeUniverses(w, universes...)
+	})
+}
+
+// runUniverseGetE gets a universe
+func runUniverseGetE(c *cobra.Command, args []string) error {
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	universe, err := client.Universe(context.Background(), args[0])
+	if err != nil {
+		return err
+	}
+
+	return render(universe, func(w io.Writer) {
+		writeUniverses(w, *universe)
+	})
+}
+
+// runUniverseCreateE creates a universe
+func runUniverseCreateE(c *cobra.Command, args []string) error {
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	universe := &provider.Universe{Name: args[0]}
+	universe.Description, _ = c.Flags().GetString("description")
+	universe.Version, _ = c.Flags().GetString("version")
+
+	return client.CreateUniverse(context.Background(), universe)
+}
+
+// runUniverseDeleteE deletes a universe
+func runUniverseDeleteE(c *cobra.Command, args []string) error {
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	return client.DeleteUniverse(context.Background(), args[0])
+}
+
+// writeUniverses writes universes as rows of a table
+func writeUniverses(w io.Writer, universes ...provider.Universe) {
+	fmt.Fprintln(w, "UNIVERSE\tNAME\tVERSION\tDESCRIPTION")
+	for _, universe := range universes {
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\n", path.Base(universe.Href), universe.Name, universe.Version, universe.Description)
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import "time"
+
+// Publish publishes an event to the subscribers, it never blocks
+func (b *Bus) Publish(event Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.id++
+	event.ID = b.id
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+
+	b.history = append(b.history, event)
+	if len(b.history) > historySize {
+		b.history = b.history[len(b.history)-historySize:]
+	}
+
+	for sub := range b.subscriptions {
+		if !sub.filter.Match(event) {
+			continue
+		}
+
+		select {
+		case sub.c <- event:
+		default: // slow subscriber
+		}
+	}
+}
+
+// Subscribe returns a new subscription, the recent events after
+// the id are replayed first (e.g. to resume a stream)
+func (b *Bus) Subscribe(filter Filter, after uint64) *Subscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := make(chan Event, subscriptionBuffer+historySize)
+	sub := &Subscription{C: c, c: c, filter: filter, bus: b}
+
+	if after > 0 {
+		for _, event := range b.history {
+			if event.ID > after && filter.Match(event) {
+				c <- event
+			}
+		}
+	}
+
+	b.subscriptions[sub] = struct{}{}
+
+	return sub
+}
+
+// Close ends a subscription
+func (s *Subscription) Close() {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+
+	if _, ok := s.bus.subscriptions[s]; ok {
+		delete(s.bus.subscriptions, s)
+		close(s.c)
+	}
+}
+
+// Match checks if an event matches the filter
+func (f Filter) Match(event Event) bool {
+	if f.Universe != "" && f.Universe != event.Universe {
+		return false
+	}
+
+	if f.Job != "" && f.Job != event.Job {
+		return false
+	}
+
+	if len(f.Types) == 0 {
+		return true
+	}
+
+	for _, t := range f.Types {
+		if t == event.Type {
+			return true
+		}
+	}
+
+	return false
+}
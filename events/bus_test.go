@@ -0,0 +1,112 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events_test
+
+import (
+	"time"
+
+	"github.com/axelspringer/moppi/events"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Bus", func() {
+	var bus *events.Bus
+
+	BeforeEach(func() {
+		bus = events.New()
+	})
+
+	It("publishes the events to the subscribers", func() {
+		sub := bus.Subscribe(events.Filter{}, 0)
+		defer sub.Close()
+
+		bus.Publish(events.Event{Type: events.TypeJob, Job: "1"})
+
+		var event events.Event
+		Eventually(sub.C).Should(Receive(&event))
+		Expect(event.ID).To(Equal(uint64(1)))
+		Expect(event.Job).To(Equal("1"))
+		Expect(event.Time).NotTo(BeZero())
+	})
+
+	It("keeps the time of an event", func() {
+		sub := bus.Subscribe(events.Filter{}, 0)
+		defer sub.Close()
+
+		t := time.Date(2017, 10, 1, 2, 0, 0, 0, time.UTC)
+		bus.Publish(events.Event{Type: events.TypeJob, Time: t})
+
+		var event events.Event
+		Eventually(sub.C).Should(Receive(&event))
+		Expect(event.Time).To(Equal(t))
+	})
+
+	It("filters the events of a subscription", func() {
+		sub := bus.Subscribe(events.Filter{Universe: "prod", Types: []string{events.TypeApp}}, 0)
+		defer sub.Close()
+
+		bus.Publish(events.Event{Type: events.TypeApp, Universe: "dev"})
+		bus.Publish(events.Event{Type: events.TypeJob, Universe: "prod"})
+		bus.Publish(events.Event{Type: events.TypeApp, Universe: "prod", App: "/nginx"})
+
+		var event events.Event
+		Eventually(sub.C).Should(Receive(&event))
+		Expect(event.App).To(Equal("/nginx"))
+		Consistently(sub.C).ShouldNot(Receive())
+	})
+
+	It("replays the events after an id", func() {
+		for i := 0; i < 3; i++ {
+			bus.Publish(events.Event{Type: events.TypeJob})
+		}
+
+		sub := bus.Subscribe(events.Filter{}, 1)
+		defer sub.Close()
+
+		var event events.Event
+		Eventually(sub.C).Should(Receive(&event))
+		Expect(event.ID).To(Equal(uint64(2)))
+		Eventually(sub.C).Should(Receive(&event))
+		Expect(event.ID).To(Equal(uint64(3)))
+		Consistently(sub.C).ShouldNot(Receive())
+	})
+
+	It("does not block on a slow subscriber", func() {
+		sub := bus.Subscribe(events.Filter{}, 0)
+		defer sub.Close()
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for i := 0; i < 1000; i++ {
+				bus.Publish(events.Event{Type: events.TypeJob})
+			}
+		}()
+
+		Eventually(done).Should(BeClosed())
+	})
+
+	It("closes the channel of a closed subscription", func() {
+		sub := bus.Subscribe(events.Filter{}, 0)
+		sub.Close()
+		sub.Close()
+
+		bus.Publish(events.Event{Type: events.TypeJob})
+
+		Eventually(sub.C).Should(BeClosed())
+	})
+})
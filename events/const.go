@@ -0,0 +1,54 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+const (
+	// TypeJob is the type of a state transition of a job
+	TypeJob = "job"
+	// TypeApp is the type of the progress of a Marathon app in an installment
+	TypeApp = "app"
+	// TypeUniverse is the type of a mutation of a universe
+	TypeUniverse = "universe"
+	// TypePackage is the type of a mutation of a package
+	TypePackage = "package"
+	// TypeRevision is the type of a mutation of a package revision
+	TypeRevision = "revision"
+)
+
+const (
+	// SourceAPI is the source of a mutation through the API
+	SourceAPI = "api"
+	// SourceKV is the source of a change in the KV, also one made directly in the KV
+	SourceKV = "kv"
+)
+
+const (
+	// AppStarted is the state of an app, which deployment has started
+	AppStarted = "started"
+	// AppWaiting is the state of an app, which deployment is waited on
+	AppWaiting = "waiting"
+	// AppHealthy is the state of a deployed and healthy app
+	AppHealthy = "healthy"
+	// AppFailed is the state of an app, which deployment failed
+	AppFailed = "failed"
+)
+
+const (
+	// subscriptionBuffer is the number of events buffered for a subscriber,
+	// events are dropped for slow subscribers
+	subscriptionBuffer = 64
+	// historySize is the number of recent events kept for a replay
+	historySize = 256
+)
@@ -0,0 +1,60 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+// Event describes something that happened in moppi
+type Event struct {
+	ID       uint64    `json:"id"`
+	Type     string    `json:"type"`
+	Time     time.Time `json:"time"`
+	Universe string    `json:"universe,omitempty"`
+	Package  string    `json:"package,omitempty"`
+	Revision string    `json:"revision,omitempty"`
+	Job      string    `json:"job,omitempty"`
+	Kind     string    `json:"kind,omitempty"`
+	App      string    `json:"app,omitempty"`
+	State    string    `json:"state,omitempty"`
+	Identity string    `json:"identity,omitempty"`
+	Source   string    `json:"source,omitempty"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// Filter filters the events of a subscription, empty fields match everything
+type Filter struct {
+	Universe string
+	Job      string
+	Types    []string
+}
+
+// Subscription receives the published events, that match its filter
+type Subscription struct {
+	C      <-chan Event
+	c      chan Event
+	filter Filter
+	bus    *Bus
+}
+
+// Bus is an in-process bus of events
+type Bus struct {
+	subscriptions map[*Subscription]struct{}
+	history       []Event
+	id            uint64
+	mu            sync.Mutex
+}
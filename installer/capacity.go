@@ -0,0 +1,88 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package installer
+
+import (
+	"github.com/axelspringer/moppi/mesos"
+	marathon "github.com/gambol99/go-marathon"
+)
+
+// CheckCapacity checks that the Marathon apps and pods can be scheduled with the free resources
+// of the accepted roles, an update only checks that each instance fits on an agent,
+// because it replaces resources, that are already used
+func (i *Installer) CheckCapacity(apps []*marathon.Application, pods []*marathon.Pod, update bool) error {
+	if i.Mesos == nil || len(apps)+len(pods) == 0 {
+		return nil
+	}
+
+	wants := make([]*want, 0, len(apps)+len(pods))
+	for _, app := range apps {
+		wants = append(wants, appWant(app))
+	}
+
+	for _, pod := range pods {
+		wants = append(wants, podWant(pod))
+	}
+
+	capacities := make(map[string][]*mesos.Capacity)
+	demands := make(map[string]mesos.Resources)
+	for _, want := range wants {
+		key := roleKey(want.roles)
+		if _, ok := capacities[key]; !ok {
+			capacity, err := i.Mesos.Capacity(splitRoles(key))
+			if err != nil {
+				return err
+			}
+			capacities[key] = capacity
+		}
+
+		if !fits(want.instance, capacities[key]) {
+			return ErrUnschedulable{App: want.id, Resources: want.instance}
+		}
+
+		demand := demands[key]
+		for n := 0; n < want.instances; n++ {
+			demand = demand.Add(want.instance)
+		}
+		demands[key] = demand
+	}
+
+	if update {
+		return nil
+	}
+
+	for key, demand := range demands {
+		var free mesos.Resources
+		for _, capacity := range capacities[key] {
+			free = free.Add(capacity.Free)
+		}
+
+		if !demand.Fits(free) {
+			return ErrCapacity{Roles: key, Demand: demand, Free: free}
+		}
+	}
+
+	return nil
+}
+
+// GroupApps returns the apps of a Marathon group and its nested groups
+func GroupApps(group *marathon.Group) []*marathon.Application {
+	apps := append([]*marathon.Application{}, group.Apps...)
+	for _, nested := range group.Groups {
+		apps = append(apps, GroupApps(nested)...)
+	}
+
+	return apps
+}
@@ -0,0 +1,142 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package installer_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	marathon "github.com/gambol99/go-marathon"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/axelspringer/moppi/cfg"
+	"github.com/axelspringer/moppi/installer"
+	"github.com/axelspringer/moppi/mesos"
+)
+
+var _ = Describe("Capacity", func() {
+	var (
+		ts *httptest.Server
+		i  *installer.Installer
+	)
+
+	BeforeEach(func() {
+		// two agents with 4 free cpus of 8 and 4 unreserved, and 4 reserved for spark
+		ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path != "/master/state" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			agent := func(hostname string) *mesos.Agent {
+				return &mesos.Agent{
+					Hostname:            hostname,
+					Active:              true,
+					Resources:           mesos.Resources{CPUs: 8, Mem: 16384, Disk: 100000},
+					UsedResources:       mesos.Resources{CPUs: 4},
+					UnreservedResources: mesos.Resources{CPUs: 4, Mem: 8192, Disk: 50000},
+					ReservedResources:   map[string]mesos.Resources{"spark": {CPUs: 4, Mem: 8192, Disk: 50000}},
+				}
+			}
+			json.NewEncoder(w).Encode(&mesos.State{Agents: []*mesos.Agent{agent("agent-1"), agent("agent-2")}})
+		}))
+
+		var err error
+		i, err = installer.New(&cfg.Config{Marathon: "http://127.0.0.1:8080", Mesos: ts.URL})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		ts.Close()
+	})
+
+	app := func(id string, cpus float64, instances int, roles ...string) *marathon.Application {
+		mem := 1024.0
+		return &marathon.Application{ID: id, CPUs: cpus, Mem: &mem, Instances: &instances, AcceptedResourceRoles: roles}
+	}
+
+	It("accepts apps, that fit into the free resources", func() {
+		Expect(i.CheckCapacity([]*marathon.Application{app("/web", 1, 4), app("/db", 2, 2)}, nil, false)).To(Succeed())
+	})
+
+	It("rejects an app, that fits on no agent", func() {
+		err := i.CheckCapacity([]*marathon.Application{app("/web", 6, 1)}, nil, false)
+		Expect(err).To(Equal(installer.ErrUnschedulable{App: "/web", Resources: mesos.Resources{CPUs: 6, Mem: 1024}}))
+	})
+
+	It("rejects apps, that need more than the free resources of their roles", func() {
+		err := i.CheckCapacity([]*marathon.Application{app("/web", 1, 6), app("/db", 2, 2)}, nil, false)
+		Expect(err).To(BeAssignableToTypeOf(installer.ErrCapacity{}))
+		Expect(err.(installer.ErrCapacity).Demand.CPUs).To(Equal(10.0))
+		Expect(err.(installer.ErrCapacity).Free.CPUs).To(Equal(8.0))
+	})
+
+	It("adds the reserved resources of the accepted roles", func() {
+		Expect(i.CheckCapacity([]*marathon.Application{app("/driver", 6, 1, "*", "spark")}, nil, false)).To(Succeed())
+	})
+
+	It("only checks that each instance fits on an agent on an update", func() {
+		Expect(i.CheckCapacity([]*marathon.Application{app("/web", 1, 12)}, nil, true)).To(Succeed())
+		Expect(i.CheckCapacity([]*marathon.Application{app("/web", 6, 1)}, nil, true)).NotTo(Succeed())
+	})
+
+	pod := func(id string, cpus float64, instances int, roles ...string) *marathon.Pod {
+		return &marathon.Pod{
+			ID: id,
+			Containers: []*marathon.PodContainer{
+				{Name: "app", Resources: &marathon.Resources{Cpus: cpus, Mem: 512}},
+				{Name: "sidecar", Resources: &marathon.Resources{Cpus: 0.5, Mem: 256}},
+			},
+			ExecutorResources: &marathon.ExecutorResources{Cpus: 0.5, Mem: 256},
+			Scaling:           &marathon.PodScalingPolicy{Kind: "fixed", Instances: instances},
+			Scheduling:        &marathon.PodSchedulingPolicy{Placement: &marathon.PodPlacement{AcceptedResourceRoles: roles}},
+		}
+	}
+
+	It("adds the containers and the executor of each pod instance to the demand", func() {
+		Expect(i.CheckCapacity(nil, []*marathon.Pod{pod("/cache", 1, 4)}, false)).To(Succeed())
+
+		err := i.CheckCapacity([]*marathon.Application{app("/web", 1, 1)}, []*marathon.Pod{pod("/cache", 1, 4)}, false)
+		Expect(err).To(BeAssignableToTypeOf(installer.ErrCapacity{}))
+		Expect(err.(installer.ErrCapacity).Demand).To(Equal(mesos.Resources{CPUs: 9, Mem: 5120}))
+	})
+
+	It("rejects a pod, which containers fit on no agent together", func() {
+		err := i.CheckCapacity(nil, []*marathon.Pod{pod("/cache", 3.5, 1)}, false)
+		Expect(err).To(Equal(installer.ErrUnschedulable{App: "/cache", Resources: mesos.Resources{CPUs: 4.5, Mem: 1024}}))
+
+		Expect(i.CheckCapacity(nil, []*marathon.Pod{pod("/cache", 3.5, 1, "*", "spark")}, false)).To(Succeed())
+	})
+
+	It("counts one instance of a pod without scaling", func() {
+		single := &marathon.Pod{ID: "/cache", Containers: []*marathon.PodContainer{{Name: "app", Resources: &marathon.Resources{Cpus: 3}}}}
+		Expect(i.CheckCapacity(nil, []*marathon.Pod{single, single}, false)).To(Succeed())
+		Expect(i.CheckCapacity(nil, []*marathon.Pod{single, single, single}, false)).NotTo(Succeed())
+	})
+
+	It("returns the apps of nested groups", func() {
+		group := &marathon.Group{
+			ID:     "/shop",
+			Apps:   []*marathon.Application{{ID: "/shop/web"}},
+			Groups: []*marathon.Group{{ID: "/shop/data", Apps: []*marathon.Application{{ID: "/shop/data/db"}}}},
+		}
+
+		apps := installer.GroupApps(group)
+		Expect(apps).To(HaveLen(2))
+		Expect(apps[1].ID).To(Equal("/shop/data/db"))
+	})
+})
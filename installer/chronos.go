@@ -0,0 +1,212 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package installer
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	chronos "github.com/axelspringer/go-chronos"
+)
+
+var (
+	// repetitions matches the repetitions of an ISO8601 schedule, R is unlimited
+	repetitions = regexp.MustCompile(`^R[0-9]*$`)
+	// duration matches an ISO8601 duration
+	duration = regexp.MustCompile(`^P([0-9]+Y)?([0-9]+M)?([0-9]+W)?([0-9]+D)?(T([0-9]+H)?([0-9]+M)?([0-9]+(\.[0-9]+)?S)?)?$`)
+	// starts are the layouts of the ISO8601 start of a schedule, with or without
+	// seconds and zone, the seconds can have a fraction
+	starts = []string{
+		"2006-01-02T15:04:05Z07:00",
+		"2006-01-02T15:04:05Z0700",
+		"2006-01-02T15:04:05",
+		"2006-01-02T15:04Z07:00",
+		"2006-01-02T15:04Z0700",
+		"2006-01-02T15:04",
+		"2006-01-02",
+	}
+)
+
+// ChronosJobs returns the names of the existing Chronos jobs
+func (i *Installer) ChronosJobs() (map[string]bool, error) {
+	res, err := i.chronosHTTP.Get(i.chronosURL + chronosJobs)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, ErrUnreachable{"Chronos", res.StatusCode}
+	}
+
+	var jobs []struct {
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&jobs); err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool, len(jobs))
+	for _, job := range jobs {
+		names[job.Name] = true
+	}
+
+	return names, nil
+}
+
+// PutChronosJob creates a Chronos job, or updates it, when it exists, a job
+// with parents is created as a dependent job, otherwise as a scheduled job
+func (i *Installer) PutChronosJob(job *chronos.Job) error {
+	path := chronosScheduled
+	if len(job.Parents) > 0 {
+		path = chronosDependent
+	}
+
+	body, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+
+	return i.chronos(http.MethodPost, path, body)
+}
+
+// RunChronosJob starts a Chronos job once right now
+func (i *Installer) RunChronosJob(name string) error {
+	return i.chronos(http.MethodPut, chronosJob+url.PathEscape(name), nil)
+}
+
+// DeleteChronosJob deletes a Chronos job, a missing job is deleted already
+func (i *Installer) DeleteChronosJob(name string) error {
+	err := i.chronos(http.MethodDelete, chronosJob+url.PathEscape(name), nil)
+	if status, ok := err.(ErrUnreachable); ok && status.Status == http.StatusNotFound {
+		return nil
+	}
+
+	return err
+}
+
+// chronos sends a request to Chronos, and fails when it is not successful
+func (i *Installer) chronos(method string, path string, body []byte) error {
+	req, err := http.NewRequest(method, i.chronosURL+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := i.chronosHTTP.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusBadRequest {
+		return ErrUnreachable{"Chronos", res.StatusCode}
+	}
+
+	return nil
+}
+
+// ValidateChronosJob validates the ISO8601 schedule of a Chronos job, that has
+// no parents, e.g. R/2017-10-01T02:00:00Z/P1D, R/2017-10-01T02:00/P1D, or R//P1D
+// to start right away, a dependent job has no schedule
+func ValidateChronosJob(job *chronos.Job) error {
+	if len(job.Parents) > 0 {
+		if job.Schedule != "" {
+			return ErrSchedule{job.Name, job.Schedule}
+		}
+
+		return nil
+	}
+
+	parts := strings.Split(job.Schedule, "/")
+	if len(parts) != 3 || !repetitions.MatchString(parts[0]) {
+		return ErrSchedule{job.Name, job.Schedule}
+	}
+
+	if parts[1] != "" && !start(parts[1]) {
+		return ErrSchedule{job.Name, job.Schedule}
+	}
+
+	if parts[2] == "P" || strings.HasSuffix(parts[2], "T") || !duration.MatchString(parts[2]) {
+		return ErrSchedule{job.Name, job.Schedule}
+	}
+
+	return nil
+}
+
+// SortChronosJobs validates the Chronos jobs, and sorts them in the order they
+// depend on each other, parents outside of the jobs are expected to exist
+func SortChronosJobs(jobs []chronos.Job) ([]chronos.Job, error) {
+	byName := make(map[string]chronos.Job, len(jobs))
+	for _, job := range jobs {
+		if err := ValidateChronosJob(&job); err != nil {
+			return nil, err
+		}
+		byName[job.Name] = job
+	}
+
+	sorted := make([]chronos.Job, 0, len(jobs))
+	state := make(map[string]int) // 1 is visiting, 2 is sorted
+
+	var visit func(job chronos.Job) error
+	visit = func(job chronos.Job) error {
+		switch state[job.Name] {
+		case 1:
+			return ErrCycle(job.Name)
+		case 2:
+			return nil
+		}
+
+		state[job.Name] = 1
+		for _, parent := range job.Parents {
+			if p, ok := byName[parent]; ok {
+				if err := visit(p); err != nil {
+					return err
+				}
+			}
+		}
+		state[job.Name] = 2
+		sorted = append(sorted, job)
+
+		return nil
+	}
+
+	for _, job := range jobs {
+		if err := visit(job); err != nil {
+			return nil, err
+		}
+	}
+
+	return sorted, nil
+}
+
+// start checks that the start of a schedule is an ISO8601 date and time
+func start(s string) bool {
+	for _, layout := range starts {
+		if _, err := time.Parse(layout, s); err == nil {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,104 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package installer_test
+
+import (
+	chronos "github.com/axelspringer/go-chronos"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/axelspringer/moppi/installer"
+)
+
+var _ = Describe("Chronos", func() {
+	DescribeTable("ValidateChronosJob",
+		func(schedule string, valid bool) {
+			err := installer.ValidateChronosJob(&chronos.Job{Name: "backup", Schedule: schedule})
+			if valid {
+				Expect(err).NotTo(HaveOccurred())
+				return
+			}
+			Expect(err).To(Equal(installer.ErrSchedule{Job: "backup", Schedule: schedule}))
+		},
+		Entry("a start in UTC", "R/2017-10-01T02:00:00Z/P1D", true),
+		Entry("a start with an offset", "R/2017-10-01T02:00:00+02:00/PT12H", true),
+		Entry("a start with a basic offset", "R/2017-10-01T02:00:00+0200/PT12H", true),
+		Entry("a start with a fraction of a second", "R/2017-10-01T02:00:00.500Z/PT30S", true),
+		Entry("a start without a zone", "R/2017-10-01T02:00:00/P1D", true),
+		Entry("a start without seconds", "R/2017-10-01T02:00/P1D", true),
+		Entry("a start without seconds in UTC", "R/2017-10-01T02:00Z/P1D", true),
+		Entry("a date as start", "R5/2017-10-01/P1W", true),
+		Entry("an empty start", "R//P1D", true),
+		Entry("limited repetitions", "R10/2017-10-01T02:00:00Z/PT2M", true),
+		Entry("no schedule", "", false),
+		Entry("no repetitions", "2017-10-01T02:00:00Z/P1D", false),
+		Entry("invalid repetitions", "X/2017-10-01T02:00:00Z/P1D", false),
+		Entry("an invalid start", "R/yesterday/P1D", false),
+		Entry("an invalid time of the start", "R/2017-10-01T25:00/P1D", false),
+		Entry("an empty duration", "R//P", false),
+		Entry("a duration without time", "R//P1DT", false),
+		Entry("an invalid duration", "R//1D", false),
+	)
+
+	It("accepts a dependent job without schedule", func() {
+		Expect(installer.ValidateChronosJob(&chronos.Job{Name: "report", Parents: []string{"backup"}})).To(Succeed())
+	})
+
+	It("rejects a dependent job with a schedule", func() {
+		job := &chronos.Job{Name: "report", Parents: []string{"backup"}, Schedule: "R//P1D"}
+		Expect(installer.ValidateChronosJob(job)).To(Equal(installer.ErrSchedule{Job: "report", Schedule: "R//P1D"}))
+	})
+
+	Describe("SortChronosJobs", func() {
+		names := func(jobs []chronos.Job) []string {
+			names := make([]string, 0, len(jobs))
+			for _, job := range jobs {
+				names = append(names, job.Name)
+			}
+
+			return names
+		}
+
+		It("sorts the parents before their dependent jobs", func() {
+			jobs, err := installer.SortChronosJobs([]chronos.Job{
+				{Name: "report", Parents: []string{"transform"}},
+				{Name: "transform", Parents: []string{"extract"}},
+				{Name: "extract", Schedule: "R//P1D"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(names(jobs)).To(Equal([]string{"extract", "transform", "report"}))
+		})
+
+		It("expects parents outside of the jobs to exist", func() {
+			jobs, err := installer.SortChronosJobs([]chronos.Job{{Name: "report", Parents: []string{"nightly"}}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(names(jobs)).To(Equal([]string{"report"}))
+		})
+
+		It("rejects a cycle", func() {
+			_, err := installer.SortChronosJobs([]chronos.Job{
+				{Name: "a", Parents: []string{"b"}},
+				{Name: "b", Parents: []string{"a"}},
+			})
+			Expect(err).To(BeAssignableToTypeOf(installer.ErrCycle("")))
+		})
+
+		It("rejects an invalid schedule", func() {
+			_, err := installer.SortChronosJobs([]chronos.Job{{Name: "backup", Schedule: "daily"}})
+			Expect(err).To(Equal(installer.ErrSchedule{Job: "backup", Schedule: "daily"}))
+		})
+	})
+})
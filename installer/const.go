@@ -0,0 +1,30 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package installer
+
+const (
+	// chronosPing is the path, that is requested to check that Chronos is reachable
+	chronosPing = "/scheduler/jobs"
+	// chronosJobs is the path of the list of the Chronos jobs
+	chronosJobs = "/scheduler/jobs"
+	// chronosJob is the path of a Chronos job
+	chronosJob = "/scheduler/job/"
+	// chronosScheduled is the path, a scheduled Chronos job is created or updated at
+	chronosScheduled = "/scheduler/iso8601"
+	// chronosDependent is the path, a dependent Chronos job is created or updated at
+	chronosDependent = "/scheduler/dependency"
+	// defaultRole is the role, an app accepts resources of by default
+	defaultRole = "*"
+)
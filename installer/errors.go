@@ -0,0 +1,92 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package installer
+
+import (
+	"fmt"
+
+	"github.com/axelspringer/moppi/mesos"
+)
+
+// ErrNoMetronome is returned when a package has Metronome jobs, but no Metronome is configured
+type ErrNoMetronome string
+
+// Error returns a custom error
+func (err ErrNoMetronome) Error() string {
+	return fmt.Sprintf("The package %v has Metronome jobs, but Metronome is not configured", string(err))
+}
+
+// ErrSchedule is returned when a Chronos job has no valid ISO8601 schedule
+type ErrSchedule struct {
+	Job      string
+	Schedule string
+}
+
+// Error returns a custom error
+func (err ErrSchedule) Error() string {
+	return fmt.Sprintf("The Chronos job %v has no valid ISO8601 schedule: %q", err.Job, err.Schedule)
+}
+
+// ErrCycle is returned when Chronos jobs depend on each other in a cycle
+type ErrCycle string
+
+// Error returns a custom error
+func (err ErrCycle) Error() string {
+	return fmt.Sprintf("The Chronos job %v is part of a cycle of dependencies", string(err))
+}
+
+// ErrNoChronosJob is returned when a Chronos job is not part of a package
+type ErrNoChronosJob string
+
+// Error returns a custom error
+func (err ErrNoChronosJob) Error() string {
+	return fmt.Sprintf("The Chronos job %v is not part of the package", string(err))
+}
+
+// ErrUnschedulable is returned when an instance of an app or a pod does not fit on any agent
+type ErrUnschedulable struct {
+	App       string
+	Resources mesos.Resources
+}
+
+// Error returns a custom error
+func (err ErrUnschedulable) Error() string {
+	return fmt.Sprintf("%v can never be scheduled, no agent has %v cpus, %v mem and %v disk for its roles",
+		err.App, err.Resources.CPUs, err.Resources.Mem, err.Resources.Disk)
+}
+
+// ErrCapacity is returned when the apps need more than the free resources of their roles
+type ErrCapacity struct {
+	Roles  string
+	Demand mesos.Resources
+	Free   mesos.Resources
+}
+
+// Error returns a custom error
+func (err ErrCapacity) Error() string {
+	return fmt.Sprintf("The package needs %v cpus, %v mem and %v disk of the roles %v, but only %v cpus, %v mem and %v disk are free",
+		err.Demand.CPUs, err.Demand.Mem, err.Demand.Disk, err.Roles, err.Free.CPUs, err.Free.Mem, err.Free.Disk)
+}
+
+// ErrUnreachable is returned when a scheduler does not answer as expected
+type ErrUnreachable struct {
+	Name   string
+	Status int
+}
+
+// Error returns a custom error
+func (err ErrUnreachable) Error() string {
+	return fmt.Sprintf("%v answered with status %d", err.Name, err.Status)
+}
@@ -13,3 +13,167 @@
 // limitations under the License.
 
 package installer
+
+import (
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/axelspringer/moppi/mesos"
+	"github.com/axelspringer/moppi/metronome"
+	marathon "github.com/gambol99/go-marathon"
+)
+
+// PingChronos checks that Chronos is reachable
+func (i *Installer) PingChronos() error {
+	res, err := i.chronosHTTP.Get(i.chronosURL + chronosPing)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return ErrUnreachable{"Chronos", res.StatusCode}
+	}
+
+	return nil
+}
+
+// CancelDeployments cancels the running Marathon deployments, that affect
+// an app or a pod, or the apps and pods in a group
+func (i *Installer) CancelDeployments(id string) error {
+	deployments, err := i.Marathon.Deployments()
+	if err != nil {
+		return err
+	}
+
+	for _, deployment := range deployments {
+		affected := append(deployment.AffectedApps, deployment.AffectedPods...)
+		for _, app := range affected {
+			if app != id && !strings.HasPrefix(app, id+"/") {
+				continue
+			}
+
+			if _, err := i.Marathon.DeleteDeployment(deployment.ID, true); err != nil {
+				return err
+			}
+			break
+		}
+	}
+
+	return nil
+}
+
+// Transient checks if an error of Marathon or Chronos is transient, like a network
+// error, a server error or a deployment lock, and the call can be retried, a conflict
+// on Chronos or Metronome is not transient
+func Transient(err error) bool {
+	switch err := err.(type) {
+	case *marathon.APIError:
+		return err.ErrCode == marathon.ErrCodeServer || err.ErrCode == marathon.ErrCodeAppLocked
+	case metronome.ErrStatus:
+		return err.Status >= http.StatusInternalServerError
+	case ErrUnreachable:
+		return err.Status == 0 || err.Status >= http.StatusInternalServerError
+	case net.Error:
+		return true
+	}
+
+	return err == marathon.ErrMarathonDown
+}
+
+// acceptedRoles returns the roles, an app accepts resources of
+func acceptedRoles(app *marathon.Application) []string {
+	if len(app.AcceptedResourceRoles) == 0 {
+		return []string{defaultRole}
+	}
+
+	return app.AcceptedResourceRoles
+}
+
+// appWant returns what an app asks of the cluster
+func appWant(app *marathon.Application) *want {
+	return &want{id: app.ID, roles: acceptedRoles(app), instance: resources(app), instances: instances(app)}
+}
+
+// podWant returns what a pod asks of the cluster, an instance of a pod
+// needs the resources of all its containers and of its executor on one agent
+func podWant(pod *marathon.Pod) *want {
+	w := &want{id: pod.ID, roles: []string{defaultRole}, instances: 1}
+
+	for _, container := range pod.Containers {
+		if container.Resources != nil {
+			w.instance = w.instance.Add(mesos.Resources{
+				CPUs: container.Resources.Cpus,
+				Mem:  container.Resources.Mem,
+				Disk: container.Resources.Disk,
+			})
+		}
+	}
+
+	if pod.ExecutorResources != nil {
+		w.instance = w.instance.Add(mesos.Resources{
+			CPUs: pod.ExecutorResources.Cpus,
+			Mem:  pod.ExecutorResources.Mem,
+			Disk: pod.ExecutorResources.Disk,
+		})
+	}
+
+	if pod.Scaling != nil {
+		w.instances = pod.Scaling.Instances
+	}
+
+	if pod.Scheduling != nil && pod.Scheduling.Placement != nil && len(pod.Scheduling.Placement.AcceptedResourceRoles) > 0 {
+		w.roles = pod.Scheduling.Placement.AcceptedResourceRoles
+	}
+
+	return w
+}
+
+// roleKey joins roles to group the demand of apps
+func roleKey(roles []string) string {
+	sorted := append([]string{}, roles...)
+	sort.Strings(sorted)
+
+	return strings.Join(sorted, ",")
+}
+
+// splitRoles splits the roles of a key
+func splitRoles(key string) []string {
+	return strings.Split(key, ",")
+}
+
+// resources returns the resources of one instance of an app
+func resources(app *marathon.Application) mesos.Resources {
+	r := mesos.Resources{CPUs: app.CPUs}
+	if app.Mem != nil {
+		r.Mem = *app.Mem
+	}
+
+	if app.Disk != nil {
+		r.Disk = *app.Disk
+	}
+
+	return r
+}
+
+// instances returns the number of instances of an app, Marathon defaults to one
+func instances(app *marathon.Application) int {
+	if app.Instances == nil {
+		return 1
+	}
+
+	return *app.Instances
+}
+
+// fits checks that one instance fits on any of the agents
+func fits(instance mesos.Resources, capacities []*mesos.Capacity) bool {
+	for _, capacity := range capacities {
+		if instance.Fits(capacity.Total) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,48 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package installer_test
+
+import (
+	"errors"
+	"net"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/axelspringer/moppi/installer"
+	"github.com/axelspringer/moppi/metronome"
+	marathon "github.com/gambol99/go-marathon"
+)
+
+var _ = DescribeTable("Transient",
+	func(err error, transient bool) {
+		Expect(installer.Transient(err)).To(Equal(transient))
+	},
+	Entry("a network error", &net.OpError{Op: "dial", Err: errors.New("connection refused")}, true),
+	Entry("Marathon is down", marathon.ErrMarathonDown, true),
+	Entry("a server error of Marathon", &marathon.APIError{ErrCode: marathon.ErrCodeServer}, true),
+	Entry("a deployment lock of Marathon", &marathon.APIError{ErrCode: marathon.ErrCodeAppLocked}, true),
+	Entry("an existing app on Marathon", &marathon.APIError{ErrCode: marathon.ErrCodeDuplicateID}, false),
+	Entry("an invalid app on Marathon", &marathon.APIError{ErrCode: marathon.ErrCodeInvalidBean}, false),
+	Entry("a server error of Chronos", installer.ErrUnreachable{Name: "Chronos", Status: http.StatusBadGateway}, true),
+	Entry("no answer of Chronos", installer.ErrUnreachable{Name: "Chronos"}, true),
+	Entry("a conflict on Chronos", installer.ErrUnreachable{Name: "Chronos", Status: http.StatusConflict}, false),
+	Entry("a bad request to Chronos", installer.ErrUnreachable{Name: "Chronos", Status: http.StatusBadRequest}, false),
+	Entry("a server error of Metronome", metronome.ErrStatus{Status: http.StatusServiceUnavailable}, true),
+	Entry("a conflict on Metronome", metronome.ErrStatus{Status: http.StatusConflict}, false),
+	Entry("a missing job on Metronome", metronome.ErrStatus{Status: http.StatusNotFound}, false),
+	Entry("any other error", errors.New("invalid package"), false),
+)
@@ -0,0 +1,47 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package installer
+
+// FrameworkActive checks that a Mesos framework is registered, and active
+func (i *Installer) FrameworkActive(name string) (bool, error) {
+	frameworks, err := i.Mesos.FindFrameworks(name)
+	if err != nil {
+		return false, err
+	}
+
+	for _, framework := range frameworks {
+		if framework.Active {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// TeardownFramework shuts down the Mesos frameworks of a name, and kills their tasks
+func (i *Installer) TeardownFramework(name string) error {
+	frameworks, err := i.Mesos.FindFrameworks(name)
+	if err != nil {
+		return err
+	}
+
+	for _, framework := range frameworks {
+		if err := i.Mesos.Shutdown(framework.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
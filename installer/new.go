@@ -16,10 +16,13 @@ package installer
 
 import (
 	"net/http"
+	"strings"
 
 	chronos "github.com/axelspringer/go-chronos"
 	"github.com/axelspringer/moppi/cfg"
 	"github.com/axelspringer/moppi/mesos"
+	"github.com/axelspringer/moppi/metrics"
+	"github.com/axelspringer/moppi/metronome"
 	marathon "github.com/gambol99/go-marathon"
 )
 
@@ -28,24 +31,43 @@ func New(config *cfg.Config) (*Installer, error) {
 	// create new Marathon client
 	marathonConfig := marathon.NewDefaultConfig()
 	marathonConfig.URL = config.Marathon
+	marathonConfig.HTTPClient = &http.Client{
+		Transport: metrics.Transport(metrics.ClientMarathon, nil),
+	}
 	marathonClient, err := marathon.NewClient(marathonConfig)
 	if err != nil {
 		return nil, err
 	}
 
 	// creating new Mesos client
-	httpClient := &http.Client{}
-	mesosClient := mesos.New(httpClient, config.Mesos)
+	mesosClient := mesos.New(&http.Client{
+		Transport: metrics.Transport(metrics.ClientMesos, nil),
+	}, config.Mesos)
 
 	// creating new Chronos client
-	chronosClient := chronos.New(config.Chronos, httpClient)
+	chronosHTTP := &http.Client{
+		Transport: metrics.Transport(metrics.ClientChronos, nil),
+	}
+	chronosClient := chronos.New(config.Chronos, chronosHTTP)
+
+	// creating new Metronome client, when it is configured
+	var metronomeClient *metronome.Metronome
+	if config.Metronome != "" {
+		metronomeClient = metronome.New(&http.Client{
+			Transport: metrics.Transport(metrics.ClientMetronome, nil),
+		}, config.Metronome)
+	}
 
 	// creating installer
 	installer := &Installer{
-		Chronos:  chronosClient,
-		Log:      cfg.Log,
-		Marathon: marathonClient,
-		Mesos:    mesosClient,
+		Chronos:   chronosClient,
+		Log:       cfg.Log,
+		Marathon:  marathonClient,
+		Mesos:     mesosClient,
+		Metronome: metronomeClient,
+
+		chronosURL:  strings.TrimSuffix(config.Chronos, "/"),
+		chronosHTTP: chronosHTTP,
 	}
 
 	return installer, nil
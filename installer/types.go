@@ -15,16 +15,31 @@
 package installer
 
 import (
+	"net/http"
+
 	chronos "github.com/axelspringer/go-chronos"
 	"github.com/axelspringer/moppi/mesos"
+	"github.com/axelspringer/moppi/metronome"
 	marathon "github.com/gambol99/go-marathon"
 	log "github.com/sirupsen/logrus"
 )
 
 // Installer describes an installer
 type Installer struct {
-	Chronos  *chronos.Client
-	Log      *log.Logger
-	Marathon marathon.Marathon
-	Mesos    *mesos.Mesos
+	Chronos   *chronos.Client
+	Log       *log.Logger
+	Marathon  marathon.Marathon
+	Mesos     *mesos.Mesos
+	Metronome *metronome.Metronome // only set, when it is configured
+
+	chronosURL  string
+	chronosHTTP *http.Client
+}
+
+// want is what an app or a pod asks of the cluster
+type want struct {
+	id        string
+	roles     []string
+	instance  mesos.Resources
+	instances int
 }
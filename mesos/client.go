@@ -40,7 +40,7 @@ func mustNew(httpClient *http.Client, url *string) *Mesos {
 func (m *Mesos) State() (*State, error) {
 	state := new(State)
 
-	_, err := m.httpClient.Get("/master/state").ReceiveSuccess(state)
+	_, err := m.httpClient.New().Get("/master/state").ReceiveSuccess(state)
 	if err != nil {
 		return nil, err
 	}
@@ -70,16 +70,18 @@ func (m *Mesos) CompletedFrameworks() ([]*Framework, error) {
 	return state.CompletedFrameworks, nil
 }
 
-// Shutdown is shutting down a framework
+// Shutdown is shutting down a framework, and kills all its tasks
 func (m *Mesos) Shutdown(frameworkID string) error {
-	data := fmt.Sprintf("frameworkId=%s", frameworkID)
-	body := strings.NewReader(data)
+	res, err := m.httpClient.New().Post("/master/teardown").BodyForm(&teardown{frameworkID}).ReceiveSuccess(nil)
+	if err != nil {
+		return err
+	}
 
-	res, err := m.httpClient.Post("/master/teardown").Body(body).Request()
-	if res.Response.StatusCode == 200 {
-		return nil
+	if res.StatusCode != http.StatusOK {
+		return ErrTeardown{frameworkID, res.StatusCode}
 	}
-	return err
+
+	return nil
 }
 
 // SearchFrameworks is searching frameworks in Mesos
@@ -0,0 +1,177 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mesos_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/axelspringer/moppi/mesos"
+)
+
+// master fakes a Mesos master, the frameworks that are torn down are recorded
+type master struct {
+	*httptest.Server
+	state       *mesos.State
+	maintenance *mesos.Maintenance
+	teardown    int // the status of a teardown
+	shutdown    []string
+	mu          sync.Mutex
+}
+
+// newMaster starts a fake Mesos master
+func newMaster() *master {
+	m := &master{state: &mesos.State{}, teardown: http.StatusOK}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/master/state", func(w http.ResponseWriter, req *http.Request) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		json.NewEncoder(w).Encode(m.state)
+	})
+	mux.HandleFunc("/master/maintenance/schedule", func(w http.ResponseWriter, req *http.Request) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if m.maintenance == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(m.maintenance)
+	})
+	mux.HandleFunc("/master/roles", func(w http.ResponseWriter, req *http.Request) {
+		json.NewEncoder(w).Encode(&mesos.Roles{Roles: []*mesos.Role{{Name: "spark", Weight: 2, Frameworks: []string{"spark-1"}}}})
+	})
+	mux.HandleFunc("/master/quota", func(w http.ResponseWriter, req *http.Request) {
+		json.NewEncoder(w).Encode(&mesos.Quotas{Infos: []*mesos.Quota{{
+			Role:      "spark",
+			Guarantee: []*mesos.Resource{{Name: "cpus", Type: "SCALAR", Scalar: &mesos.Scalar{Value: 8}}},
+		}}})
+	})
+	mux.HandleFunc("/master/teardown", func(w http.ResponseWriter, req *http.Request) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if req.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		m.shutdown = append(m.shutdown, req.FormValue("frameworkId"))
+		w.WriteHeader(m.teardown)
+	})
+	m.Server = httptest.NewServer(mux)
+
+	return m
+}
+
+var _ = Describe("Client", func() {
+	var (
+		m      *master
+		client *mesos.Mesos
+	)
+
+	BeforeEach(func() {
+		m = newMaster()
+		m.state.Frameworks = []*mesos.Framework{
+			{ID: "marathon-1", Name: "marathon", Active: true},
+			{ID: "spark-1", Name: "Spark", Active: true, Tasks: []*mesos.Task{{ID: "driver", State: "TASK_RUNNING"}}},
+			{ID: "spark-2", Name: "spark", CompletedTasks: []*mesos.Task{{ID: "executor", State: "TASK_FINISHED"}}},
+		}
+		client = mesos.New(http.DefaultClient, m.URL)
+	})
+
+	AfterEach(func() {
+		m.Close()
+	})
+
+	It("gets the state of the master", func() {
+		state, err := client.State()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state.Frameworks).To(HaveLen(3))
+	})
+
+	It("gets the state from several goroutines at once", func() {
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+
+				_, err := client.State()
+				Expect(err).NotTo(HaveOccurred())
+				_, err = client.Roles()
+				Expect(err).NotTo(HaveOccurred())
+			}()
+		}
+		wg.Wait()
+	})
+
+	It("finds the frameworks by their name, ignoring the case", func() {
+		frameworks, err := client.FindFrameworks("spark")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(frameworks).To(HaveLen(2))
+
+		framework, err := client.FindFramework("marathon")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(framework.ID).To(Equal("marathon-1"))
+
+		framework, err = client.FindFramework("kafka")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(framework).To(BeNil())
+
+		_, err = client.FindFramework("spark")
+		Expect(err).To(HaveOccurred()) // ambiguous
+	})
+
+	It("gets the running and completed tasks of the frameworks", func() {
+		tasks, err := client.Tasks()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tasks).To(HaveLen(1))
+		Expect(tasks[0].ID).To(Equal("driver"))
+
+		tasks, err = client.CompletedTasks()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tasks).To(HaveLen(1))
+		Expect(tasks[0].ID).To(Equal("executor"))
+	})
+
+	It("gets the roles and their quotas", func() {
+		roles, err := client.Roles()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(roles).To(HaveLen(1))
+		Expect(roles[0].Name).To(Equal("spark"))
+
+		quotas, err := client.Quotas()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(quotas).To(HaveLen(1))
+		Expect(quotas[0].Guarantee[0].Scalar.Value).To(Equal(8.0))
+	})
+
+	It("shuts down a framework", func() {
+		Expect(client.Shutdown("spark-1")).To(Succeed())
+		Expect(m.shutdown).To(Equal([]string{"spark-1"}))
+	})
+
+	It("fails, when Mesos does not shut down a framework", func() {
+		m.teardown = http.StatusBadRequest
+
+		err := client.Shutdown("spark-3")
+		Expect(err).To(Equal(mesos.ErrTeardown{Framework: "spark-3", Status: http.StatusBadRequest}))
+	})
+})
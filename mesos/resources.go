@@ -0,0 +1,194 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mesos
+
+import (
+	"math"
+	"time"
+)
+
+// Agents gets the Mesos agents with their resources
+func (m *Mesos) Agents() ([]*Agent, error) {
+	state, err := m.State()
+	if err != nil {
+		return []*Agent{}, err
+	}
+
+	return state.Agents, nil
+}
+
+// Tasks gets the running tasks of all frameworks
+func (m *Mesos) Tasks() ([]*Task, error) {
+	frameworks, err := m.Frameworks()
+	if err != nil {
+		return []*Task{}, err
+	}
+
+	var tasks []*Task
+	for _, framework := range frameworks {
+		tasks = append(tasks, framework.Tasks...)
+	}
+
+	return tasks, nil
+}
+
+// CompletedTasks gets the completed tasks of all frameworks
+func (m *Mesos) CompletedTasks() ([]*Task, error) {
+	frameworks, err := m.Frameworks()
+	if err != nil {
+		return []*Task{}, err
+	}
+
+	var tasks []*Task
+	for _, framework := range frameworks {
+		tasks = append(tasks, framework.CompletedTasks...)
+	}
+
+	return tasks, nil
+}
+
+// Roles gets the Mesos roles
+func (m *Mesos) Roles() ([]*Role, error) {
+	roles := new(Roles)
+
+	_, err := m.httpClient.New().Get("/master/roles").ReceiveSuccess(roles)
+	if err != nil {
+		return []*Role{}, err
+	}
+
+	return roles.Roles, nil
+}
+
+// Quotas gets the quotas of the Mesos roles
+func (m *Mesos) Quotas() ([]*Quota, error) {
+	quotas := new(Quotas)
+
+	_, err := m.httpClient.New().Get("/master/quota").ReceiveSuccess(quotas)
+	if err != nil {
+		return []*Quota{}, err
+	}
+
+	return quotas.Infos, nil
+}
+
+// Maintenance gets the maintenance schedule of the Mesos agents
+func (m *Mesos) Maintenance() (*Maintenance, error) {
+	maintenance := new(Maintenance)
+
+	_, err := m.httpClient.New().Get("/master/maintenance/schedule").ReceiveSuccess(maintenance)
+	if err != nil {
+		return nil, err
+	}
+
+	return maintenance, nil
+}
+
+// Capacity gets the resources of the roles on the active agents, which are not in
+// maintenance, the free resources are the resources of the roles, that are not used
+func (m *Mesos) Capacity(roles []string) ([]*Capacity, error) {
+	state, err := m.State()
+	if err != nil {
+		return []*Capacity{}, err
+	}
+
+	// the maintenance schedule is optional
+	down := make(map[string]bool)
+	if maintenance, err := m.Maintenance(); err == nil {
+		down = maintenance.Unavailable(time.Now())
+	}
+
+	capacities := make([]*Capacity, 0, len(state.Agents))
+	for _, agent := range state.Agents {
+		if !agent.Active || down[agent.Hostname] {
+			continue
+		}
+
+		var total Resources
+		for _, role := range roles {
+			if role == "*" {
+				total = total.Add(agent.UnreservedResources)
+				continue
+			}
+			total = total.Add(agent.ReservedResources[role])
+		}
+
+		unused := agent.Resources.Sub(agent.UsedResources)
+		capacities = append(capacities, &Capacity{
+			Agent: agent.Hostname,
+			Total: total,
+			Free:  total.Min(unused),
+		})
+	}
+
+	return capacities, nil
+}
+
+// Unavailable returns the hostnames of the agents, that are in maintenance at a time
+func (m *Maintenance) Unavailable(at time.Time) map[string]bool {
+	now := at.UnixNano()
+
+	down := make(map[string]bool)
+	for _, window := range m.Windows {
+		start := window.Unavailability.Start.Nanoseconds
+		if now < start {
+			continue
+		}
+
+		if duration := window.Unavailability.Duration; duration != nil && now >= start+duration.Nanoseconds {
+			continue
+		}
+
+		for _, machine := range window.Machines {
+			down[machine.Hostname] = true
+		}
+	}
+
+	return down
+}
+
+// Add adds scalar resources
+func (r Resources) Add(o Resources) Resources {
+	return Resources{
+		CPUs: r.CPUs + o.CPUs,
+		Mem:  r.Mem + o.Mem,
+		Disk: r.Disk + o.Disk,
+		GPUs: r.GPUs + o.GPUs,
+	}
+}
+
+// Sub subtracts scalar resources
+func (r Resources) Sub(o Resources) Resources {
+	return Resources{
+		CPUs: r.CPUs - o.CPUs,
+		Mem:  r.Mem - o.Mem,
+		Disk: r.Disk - o.Disk,
+		GPUs: r.GPUs - o.GPUs,
+	}
+}
+
+// Min returns the smaller of each scalar resource
+func (r Resources) Min(o Resources) Resources {
+	return Resources{
+		CPUs: math.Min(r.CPUs, o.CPUs),
+		Mem:  math.Min(r.Mem, o.Mem),
+		Disk: math.Min(r.Disk, o.Disk),
+		GPUs: math.Min(r.GPUs, o.GPUs),
+	}
+}
+
+// Fits checks that the cpus, mem and disk of the resources fit into others
+func (r Resources) Fits(o Resources) bool {
+	return r.CPUs <= o.CPUs && r.Mem <= o.Mem && r.Disk <= o.Disk
+}
@@ -0,0 +1,123 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mesos_test
+
+import (
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/axelspringer/moppi/mesos"
+)
+
+var _ = Describe("Resources", func() {
+	var (
+		m      *master
+		client *mesos.Mesos
+	)
+
+	BeforeEach(func() {
+		m = newMaster()
+		m.state.Agents = []*mesos.Agent{
+			{
+				Hostname:            "agent-1",
+				Active:              true,
+				Resources:           mesos.Resources{CPUs: 8, Mem: 16384, Disk: 100000},
+				UsedResources:       mesos.Resources{CPUs: 6, Mem: 4096, Disk: 1000},
+				UnreservedResources: mesos.Resources{CPUs: 4, Mem: 8192, Disk: 50000},
+				ReservedResources:   map[string]mesos.Resources{"spark": {CPUs: 4, Mem: 8192, Disk: 50000}},
+			},
+			{
+				Hostname:            "agent-2",
+				Active:              false,
+				Resources:           mesos.Resources{CPUs: 8, Mem: 16384, Disk: 100000},
+				UnreservedResources: mesos.Resources{CPUs: 8, Mem: 16384, Disk: 100000},
+			},
+			{
+				Hostname:            "agent-3",
+				Active:              true,
+				Resources:           mesos.Resources{CPUs: 2, Mem: 2048, Disk: 10000},
+				UnreservedResources: mesos.Resources{CPUs: 2, Mem: 2048, Disk: 10000},
+			},
+		}
+		client = mesos.New(http.DefaultClient, m.URL)
+	})
+
+	AfterEach(func() {
+		m.Close()
+	})
+
+	It("gets the agents", func() {
+		agents, err := client.Agents()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(agents).To(HaveLen(3))
+	})
+
+	It("gets the capacity of the roles on the active agents", func() {
+		capacities, err := client.Capacity([]string{"*"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(capacities).To(HaveLen(2))
+
+		Expect(capacities[0].Agent).To(Equal("agent-1"))
+		Expect(capacities[0].Total).To(Equal(mesos.Resources{CPUs: 4, Mem: 8192, Disk: 50000}))
+		Expect(capacities[0].Free).To(Equal(mesos.Resources{CPUs: 2, Mem: 8192, Disk: 50000})) // the unused resources
+		Expect(capacities[1].Free).To(Equal(mesos.Resources{CPUs: 2, Mem: 2048, Disk: 10000}))
+	})
+
+	It("adds the reserved resources of the roles", func() {
+		capacities, err := client.Capacity([]string{"*", "spark"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(capacities[0].Total).To(Equal(mesos.Resources{CPUs: 8, Mem: 16384, Disk: 100000}))
+	})
+
+	It("leaves out the agents in maintenance", func() {
+		hour := time.Hour.Nanoseconds()
+		m.maintenance = &mesos.Maintenance{Windows: []*mesos.Window{{
+			Machines:       []*mesos.Machine{{Hostname: "agent-3"}},
+			Unavailability: mesos.Unavailability{Start: mesos.Nanoseconds{Nanoseconds: time.Now().UnixNano() - hour}},
+		}}}
+
+		capacities, err := client.Capacity([]string{"*"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(capacities).To(HaveLen(1))
+		Expect(capacities[0].Agent).To(Equal("agent-1"))
+	})
+
+	Describe("a maintenance window", func() {
+		start := time.Date(2017, 10, 1, 2, 0, 0, 0, time.UTC)
+		duration := mesos.Nanoseconds{Nanoseconds: time.Hour.Nanoseconds()}
+		maintenance := &mesos.Maintenance{Windows: []*mesos.Window{{
+			Machines:       []*mesos.Machine{{Hostname: "agent-1"}},
+			Unavailability: mesos.Unavailability{Start: mesos.Nanoseconds{Nanoseconds: start.UnixNano()}, Duration: &duration},
+		}}}
+
+		It("makes the agents unavailable during the window", func() {
+			Expect(maintenance.Unavailable(start.Add(-time.Minute))).To(BeEmpty())
+			Expect(maintenance.Unavailable(start)).To(HaveKey("agent-1"))
+			Expect(maintenance.Unavailable(start.Add(59 * time.Minute))).To(HaveKey("agent-1"))
+			Expect(maintenance.Unavailable(start.Add(time.Hour))).To(BeEmpty())
+		})
+	})
+
+	It("fits resources into others by cpus, mem and disk", func() {
+		agent := mesos.Resources{CPUs: 2, Mem: 2048, Disk: 10000}
+		Expect(mesos.Resources{CPUs: 2, Mem: 2048, GPUs: 1}.Fits(agent)).To(BeTrue())
+		Expect(mesos.Resources{CPUs: 2.5}.Fits(agent)).To(BeFalse())
+		Expect(mesos.Resources{Mem: 4096}.Fits(agent)).To(BeFalse())
+		Expect(agent.Sub(mesos.Resources{CPUs: 1}).Add(mesos.Resources{Mem: 1})).To(Equal(mesos.Resources{CPUs: 1, Mem: 2049, Disk: 10000}))
+	})
+})
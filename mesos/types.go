@@ -23,12 +23,113 @@ type Mesos struct {
 
 // State holds the current State of Mesos
 type State struct {
+	Leader                 string       `json:"leader"`
+	Agents                 []*Agent     `json:"slaves"`
 	CompletedFrameworks    []*Framework `json:"completed_frameworks"`
 	Frameworks             []*Framework `json:"frameworks"`
 	UnregisteredFrameworks []string     `json:"unregistered_frameworks"`
 	Flags                  Flags        `json:"flags"`
 }
 
+// Agent holds a Mesos agent (slave) and its resources
+type Agent struct {
+	ID                  string               `json:"id"`
+	PID                 string               `json:"pid"`
+	Hostname            string               `json:"hostname"`
+	Active              bool                 `json:"active"`
+	Resources           Resources            `json:"resources"`
+	UsedResources       Resources            `json:"used_resources"`
+	OfferedResources    Resources            `json:"offered_resources"`
+	UnreservedResources Resources            `json:"unreserved_resources"`
+	ReservedResources   map[string]Resources `json:"reserved_resources"`
+}
+
+// Resources holds the scalar resources of an agent, a framework or a task
+type Resources struct {
+	CPUs  float64 `json:"cpus"`
+	Mem   float64 `json:"mem"`
+	Disk  float64 `json:"disk"`
+	GPUs  float64 `json:"gpus"`
+	Ports string  `json:"ports,omitempty"`
+}
+
+// Capacity holds the resources of an agent for a set of roles, and how much of them is free
+type Capacity struct {
+	Agent string
+	Total Resources
+	Free  Resources
+}
+
+// Role holds a Mesos role
+type Role struct {
+	Name       string    `json:"name"`
+	Weight     float64   `json:"weight"`
+	Frameworks []string  `json:"frameworks"`
+	Resources  Resources `json:"resources"`
+}
+
+// Roles holds the Mesos roles
+type Roles struct {
+	Roles []*Role `json:"roles"`
+}
+
+// Quota holds the guaranteed resources of a Mesos role
+type Quota struct {
+	Role      string      `json:"role"`
+	Guarantee []*Resource `json:"guarantee"`
+}
+
+// Quotas holds the quotas of the Mesos roles
+type Quotas struct {
+	Infos []*Quota `json:"infos"`
+}
+
+// Resource holds a named resource of Mesos
+type Resource struct {
+	Name   string  `json:"name"`
+	Type   string  `json:"type"`
+	Role   string  `json:"role,omitempty"`
+	Scalar *Scalar `json:"scalar,omitempty"`
+}
+
+// Scalar holds the value of a scalar resource
+type Scalar struct {
+	Value float64 `json:"value"`
+}
+
+// Maintenance holds the maintenance schedule of the Mesos agents
+type Maintenance struct {
+	Windows []*Window `json:"windows"`
+}
+
+// Window holds the agents, that are unavailable for a time
+type Window struct {
+	Machines       []*Machine     `json:"machine_ids"`
+	Unavailability Unavailability `json:"unavailability"`
+}
+
+// Machine holds the machine of an agent
+type Machine struct {
+	Hostname string `json:"hostname"`
+	IP       string `json:"ip"`
+}
+
+// Unavailability holds the time, an agent is unavailable
+type Unavailability struct {
+	Start    Nanoseconds  `json:"start"`
+	Duration *Nanoseconds `json:"duration,omitempty"`
+}
+
+// Nanoseconds holds a time, or a duration of Mesos
+type Nanoseconds struct {
+	Nanoseconds int64 `json:"nanoseconds"`
+}
+
+// teardown holds the form to shut down a framework
+type teardown struct {
+	FrameworkID string `url:"frameworkId"`
+}
+
 // Flags holds the flags provided to Mesos
 type Flags struct {
 	Authenticate       string `json:"authenticate"`
@@ -37,22 +138,26 @@ type Flags struct {
 
 // Framework holds a Mesos Framework
 type Framework struct {
-	Name             string  `json:"name"`
-	ID               string  `json:"id"`
-	PID              string  `json:"pid"`
-	Active           bool    `json:"active"`
-	Hostname         string  `json:"hostname"`
-	User             string  `json:"user"`
-	RegisteredTime   float64 `json:"registered_time"`
-	ReregisteredTime float64 `json:"reregistered_time"`
-	Tasks            []*Task `json:"tasks"`
+	Name             string    `json:"name"`
+	ID               string    `json:"id"`
+	PID              string    `json:"pid"`
+	Active           bool      `json:"active"`
+	Hostname         string    `json:"hostname"`
+	User             string    `json:"user"`
+	RegisteredTime   float64   `json:"registered_time"`
+	ReregisteredTime float64   `json:"reregistered_time"`
+	Role             string    `json:"role"`
+	UsedResources    Resources `json:"used_resources"`
+	Tasks            []*Task   `json:"tasks"`
+	CompletedTasks   []*Task   `json:"completed_tasks"`
 }
 
 // Task holds a Mesos Framework Task
 type Task struct {
-	FrameworkID string `json:"framework_id"`
-	ID          string `json:"id"`
-	Name        string `json:"name"`
-	SlaveID     string `json:"slave_id"`
-	State       string `json:"state"`
+	FrameworkID string    `json:"framework_id"`
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	SlaveID     string    `json:"slave_id"`
+	State       string    `json:"state"`
+	Resources   Resources `json:"resources"`
 }
@@ -0,0 +1,31 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+const (
+	// namespace is the namespace of all the metrics of moppi
+	namespace = "moppi"
+)
+
+const (
+	// ClientMarathon is the label of the calls to Marathon
+	ClientMarathon = "marathon"
+	// ClientChronos is the label of the calls to Chronos
+	ClientChronos = "chronos"
+	// ClientMetronome is the label of the calls to Metronome
+	ClientMetronome = "metronome"
+	// ClientMesos is the label of the calls to the Mesos master
+	ClientMesos = "mesos"
+)
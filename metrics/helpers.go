@@ -0,0 +1,66 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Handler returns the handler, that exposes the metrics
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// ObserveRequest observes a HTTP request to a route
+func ObserveRequest(route string, method string, code int, start time.Time) {
+	Requests.WithLabelValues(route, method, strconv.Itoa(code)).Inc()
+	RequestDuration.WithLabelValues(route, method).Observe(time.Since(start).Seconds())
+}
+
+// ObserveKV observes an operation on the KV
+func ObserveKV(operation string, start time.Time, err error) {
+	KVDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+	if err != nil {
+		KVErrors.WithLabelValues(operation).Inc()
+	}
+}
+
+// Transport wraps a http.RoundTripper, and observes the calls of a client,
+// the http.DefaultTransport is used if next is nil
+func Transport(client string, next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &transport{client, next}
+}
+
+// RoundTrip observes the latency of a call, calls that fail or
+// are answered with a server error are counted as errors
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	res, err := t.next.RoundTrip(req)
+
+	ClientDuration.WithLabelValues(t.client, req.Method).Observe(time.Since(start).Seconds())
+	if err != nil || res.StatusCode >= http.StatusInternalServerError {
+		ClientErrors.WithLabelValues(t.client, req.Method).Inc()
+	}
+
+	return res, err
+}
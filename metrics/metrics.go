@@ -0,0 +1,108 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	// Requests counts the HTTP requests by route, method and status code
+	Requests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Number of HTTP requests by route, method and status code.",
+	}, []string{"route", "method", "code"})
+
+	// RequestDuration observes the latencies of the HTTP requests by route and method
+	RequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Latencies of the HTTP requests by route and method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method"})
+
+	// QueueDepth is the number of jobs waiting for a worker
+	QueueDepth = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "queue",
+		Name:      "depth",
+		Help:      "Number of jobs waiting for a worker.",
+	})
+
+	// ActiveWorkers is the number of workers working on a job
+	ActiveWorkers = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "queue",
+		Name:      "active_workers",
+		Help:      "Number of workers working on a job.",
+	})
+
+	// Jobs counts the finished jobs by kind, universe, package and state
+	Jobs = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "queue",
+		Name:      "jobs_total",
+		Help:      "Number of finished jobs by kind, universe, package and state.",
+	}, []string{"kind", "universe", "package", "state"})
+
+	// ClientDuration observes the latencies of the calls to Marathon, Chronos and Mesos
+	ClientDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "client",
+		Name:      "request_duration_seconds",
+		Help:      "Latencies of the calls to Marathon, Chronos and Mesos by client and method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"client", "method"})
+
+	// ClientErrors counts the failed calls to Marathon, Chronos and Mesos
+	ClientErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "client",
+		Name:      "errors_total",
+		Help:      "Number of failed calls to Marathon, Chronos and Mesos by client and method.",
+	}, []string{"client", "method"})
+
+	// KVDuration observes the latencies of the operations on the KV
+	KVDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "kv",
+		Name:      "operation_duration_seconds",
+		Help:      "Latencies of the operations on the KV by operation.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"operation"})
+
+	// KVErrors counts the failed operations on the KV
+	KVErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "kv",
+		Name:      "errors_total",
+		Help:      "Number of failed operations on the KV by operation.",
+	}, []string{"operation"})
+)
+
+func init() {
+	prometheus.MustRegister(
+		Requests,
+		RequestDuration,
+		QueueDepth,
+		ActiveWorkers,
+		Jobs,
+		ClientDuration,
+		ClientErrors,
+		KVDuration,
+		KVErrors,
+	)
+}
@@ -0,0 +1,179 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metronome
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/dghubble/sling"
+)
+
+// New creates a new Metronome client
+func New(httpClient *http.Client, url string) *Metronome {
+	return mustNew(httpClient, &url)
+}
+
+// mustNew wraps the creation of a new Metronome client
+func mustNew(httpClient *http.Client, url *string) *Metronome {
+	sling := sling.New().Client(httpClient).Base(*url)
+
+	return &Metronome{
+		httpClient: sling,
+	}
+}
+
+// Ping checks that Metronome is reachable
+func (m *Metronome) Ping() error {
+	return m.do(m.httpClient.New().Get(pathPing), nil)
+}
+
+// Job gets a Metronome job with its schedules
+func (m *Metronome) Job(id string) (*Job, error) {
+	job := new(Job)
+	if err := m.do(m.httpClient.New().Get(jobPath(id)), job); err != nil {
+		return nil, err
+	}
+
+	schedules, err := m.Schedules(id)
+	if err != nil {
+		return nil, err
+	}
+	job.Schedules = schedules
+
+	return job, nil
+}
+
+// CreateJob creates a Metronome job with its schedules
+func (m *Metronome) CreateJob(job *Job) error {
+	if err := m.do(m.httpClient.New().Post(pathJobs).BodyJSON(spec(job)), nil); err != nil {
+		return err
+	}
+
+	for _, schedule := range job.Schedules {
+		if err := m.CreateSchedule(job.ID, schedule); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UpdateJob updates a Metronome job, and replaces its schedules
+func (m *Metronome) UpdateJob(job *Job) error {
+	if err := m.do(m.httpClient.New().Put(jobPath(job.ID)).BodyJSON(spec(job)), nil); err != nil {
+		return err
+	}
+
+	existing, err := m.Schedules(job.ID)
+	if err != nil {
+		return err
+	}
+
+	scheduled := make(map[string]bool)
+	for _, schedule := range existing {
+		scheduled[schedule.ID] = true
+	}
+
+	for _, schedule := range job.Schedules {
+		if scheduled[schedule.ID] {
+			err = m.UpdateSchedule(job.ID, schedule)
+		} else {
+			err = m.CreateSchedule(job.ID, schedule)
+		}
+		if err != nil {
+			return err
+		}
+		delete(scheduled, schedule.ID)
+	}
+
+	for id := range scheduled {
+		if err := m.DeleteSchedule(job.ID, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteJob deletes a Metronome job, and stops its running runs
+func (m *Metronome) DeleteJob(id string) error {
+	params := &struct {
+		Stop bool `url:"stopCurrentJobRuns"`
+	}{true}
+
+	return m.do(m.httpClient.New().Delete(jobPath(id)).QueryStruct(params), nil)
+}
+
+// Schedules gets the schedules of a Metronome job
+func (m *Metronome) Schedules(id string) ([]Schedule, error) {
+	schedules := make([]Schedule, 0)
+	if err := m.do(m.httpClient.New().Get(jobPath(id)+pathSchedules), &schedules); err != nil {
+		return nil, err
+	}
+
+	return schedules, nil
+}
+
+// CreateSchedule creates a schedule of a Metronome job
+func (m *Metronome) CreateSchedule(id string, schedule Schedule) error {
+	return m.do(m.httpClient.New().Post(jobPath(id)+pathSchedules).BodyJSON(schedule), nil)
+}
+
+// UpdateSchedule updates a schedule of a Metronome job
+func (m *Metronome) UpdateSchedule(id string, schedule Schedule) error {
+	return m.do(m.httpClient.New().Put(schedulePath(id, schedule.ID)).BodyJSON(schedule), nil)
+}
+
+// DeleteSchedule deletes a schedule of a Metronome job
+func (m *Metronome) DeleteSchedule(id string, schedule string) error {
+	return m.do(m.httpClient.New().Delete(schedulePath(id, schedule)), nil)
+}
+
+// NotFound checks if Metronome answered, that a job or a schedule does not exist
+func NotFound(err error) bool {
+	status, ok := err.(ErrStatus)
+
+	return ok && status.Status == http.StatusNotFound
+}
+
+// do sends a request to Metronome, and decodes the answer into success
+func (m *Metronome) do(req *sling.Sling, success interface{}) error {
+	failure := new(Message)
+	res, err := req.Receive(success, failure)
+	if res != nil && res.StatusCode >= http.StatusBadRequest {
+		return ErrStatus{res.StatusCode, failure.Message}
+	}
+
+	return err
+}
+
+// spec returns a Metronome job without its schedules, which are created separately
+func spec(job *Job) *Job {
+	spec := *job
+	spec.Schedules = nil
+
+	return &spec
+}
+
+// jobPath returns the path of a Metronome job
+func jobPath(id string) string {
+	return pathJobs + "/" + url.PathEscape(id)
+}
+
+// schedulePath returns the path of a schedule of a Metronome job
+func schedulePath(id string, schedule string) string {
+	return jobPath(id) + pathSchedules + "/" + url.PathEscape(schedule)
+}
@@ -0,0 +1,147 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metronome_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/axelspringer/moppi/metronome"
+)
+
+var _ = Describe("Client", func() {
+	var (
+		ts     *httptest.Server
+		client *metronome.Metronome
+		mu     sync.Mutex
+		calls  []string
+		bodies map[string]string
+		status map[string]int
+	)
+
+	BeforeEach(func() {
+		calls = nil
+		bodies = make(map[string]string)
+		status = make(map[string]int)
+
+		// the backup job exists with the nightly and the weekly schedule
+		ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			call := req.Method + " " + req.URL.RequestURI()
+			calls = append(calls, call)
+			body, _ := ioutil.ReadAll(req.Body)
+			bodies[call] = string(body)
+
+			if code, ok := status[call]; ok {
+				w.WriteHeader(code)
+				json.NewEncoder(w).Encode(&metronome.Message{Message: http.StatusText(code)})
+				return
+			}
+
+			switch call {
+			case "GET /v1/jobs/backup":
+				json.NewEncoder(w).Encode(&metronome.Job{ID: "backup", Run: metronome.Run{Cmd: "backup.sh"}})
+			case "GET /v1/jobs/backup/schedules":
+				json.NewEncoder(w).Encode([]metronome.Schedule{{ID: "nightly", Cron: "0 2 * * *"}, {ID: "weekly", Cron: "0 3 * * 0"}})
+			case "GET /v1/jobs/missing", "GET /v1/jobs/missing/schedules":
+				w.WriteHeader(http.StatusNotFound)
+				json.NewEncoder(w).Encode(&metronome.Message{Message: "Job 'missing' does not exist"})
+			default:
+				w.WriteHeader(http.StatusOK)
+			}
+		}))
+		client = metronome.New(http.DefaultClient, ts.URL)
+	})
+
+	AfterEach(func() {
+		ts.Close()
+	})
+
+	job := func() *metronome.Job {
+		return &metronome.Job{
+			ID:  "backup",
+			Run: metronome.Run{Cmd: "backup.sh", CPUs: 0.5, Mem: 128},
+			Schedules: []metronome.Schedule{
+				{ID: "nightly", Cron: "0 1 * * *", Enabled: true},
+				{ID: "hourly", Cron: "0 * * * *", Enabled: true},
+			},
+		}
+	}
+
+	It("gets a job with its schedules", func() {
+		job, err := client.Job("backup")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(job.Run.Cmd).To(Equal("backup.sh"))
+		Expect(job.Schedules).To(HaveLen(2))
+	})
+
+	It("answers that a job does not exist", func() {
+		_, err := client.Job("missing")
+		Expect(metronome.NotFound(err)).To(BeTrue())
+		Expect(err).To(Equal(metronome.ErrStatus{Status: http.StatusNotFound, Message: "Job 'missing' does not exist"}))
+	})
+
+	It("creates a job, and then its schedules", func() {
+		Expect(client.CreateJob(job())).To(Succeed())
+		Expect(calls).To(Equal([]string{
+			"POST /v1/jobs",
+			"POST /v1/jobs/backup/schedules",
+			"POST /v1/jobs/backup/schedules",
+		}))
+
+		var spec metronome.Job
+		Expect(json.Unmarshal([]byte(bodies["POST /v1/jobs"]), &spec)).To(Succeed())
+		Expect(spec.Schedules).To(BeEmpty()) // the schedules are created separately
+		Expect(spec.Run.CPUs).To(Equal(0.5))
+	})
+
+	It("updates a job, and replaces its schedules", func() {
+		Expect(client.UpdateJob(job())).To(Succeed())
+		Expect(calls).To(Equal([]string{
+			"PUT /v1/jobs/backup",
+			"GET /v1/jobs/backup/schedules",
+			"PUT /v1/jobs/backup/schedules/nightly",
+			"POST /v1/jobs/backup/schedules",
+			"DELETE /v1/jobs/backup/schedules/weekly",
+		}))
+	})
+
+	It("deletes a job, and stops its runs", func() {
+		Expect(client.DeleteJob("backup")).To(Succeed())
+		Expect(calls).To(Equal([]string{"DELETE /v1/jobs/backup?stopCurrentJobRuns=true"}))
+	})
+
+	It("escapes the ids in the path", func() {
+		Expect(client.DeleteJob("db/backup")).To(Succeed())
+		Expect(calls).To(Equal([]string{"DELETE /v1/jobs/db%2Fbackup?stopCurrentJobRuns=true"}))
+	})
+
+	It("fails with the status and the message of Metronome", func() {
+		status["POST /v1/jobs"] = http.StatusConflict
+
+		err := client.CreateJob(job())
+		Expect(err).To(Equal(metronome.ErrStatus{Status: http.StatusConflict, Message: "Conflict"}))
+		Expect(metronome.NotFound(err)).To(BeFalse())
+		Expect(calls).To(HaveLen(1)) // no schedules of a job, that was not created
+	})
+})
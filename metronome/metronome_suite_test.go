@@ -0,0 +1,13 @@
+package metronome_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestMetronome(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Metronome Suite")
+}
@@ -0,0 +1,71 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metronome
+
+import "github.com/dghubble/sling"
+
+// Metronome holds a Metronome client
+type Metronome struct {
+	httpClient *sling.Sling
+}
+
+// Job holds a Metronome job, with its schedules
+type Job struct {
+	ID          string            `json:"id"`
+	Description string            `json:"description,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Run         Run               `json:"run"`
+	Schedules   []Schedule        `json:"schedules,omitempty"`
+}
+
+// Run holds how a Metronome job is run
+type Run struct {
+	Cmd            string            `json:"cmd,omitempty"`
+	Args           []string          `json:"args,omitempty"`
+	CPUs           float64           `json:"cpus"`
+	Mem            float64           `json:"mem"`
+	Disk           float64           `json:"disk"`
+	User           string            `json:"user,omitempty"`
+	Env            map[string]string `json:"env,omitempty"`
+	MaxLaunchDelay int               `json:"maxLaunchDelay,omitempty"`
+	Docker         *Docker           `json:"docker,omitempty"`
+	Restart        *Restart          `json:"restart,omitempty"`
+}
+
+// Docker holds the image of a Metronome job
+type Docker struct {
+	Image string `json:"image"`
+}
+
+// Restart holds the restart policy of a Metronome job
+type Restart struct {
+	Policy                string `json:"policy"`
+	ActiveDeadlineSeconds int    `json:"activeDeadlineSeconds,omitempty"`
+}
+
+// Schedule holds a schedule of a Metronome job
+type Schedule struct {
+	ID                      string `json:"id"`
+	Cron                    string `json:"cron"`
+	TimeZone                string `json:"timezone,omitempty"`
+	StartingDeadlineSeconds int    `json:"startingDeadlineSeconds,omitempty"`
+	ConcurrencyPolicy       string `json:"concurrencyPolicy,omitempty"`
+	Enabled                 bool   `json:"enabled"`
+}
+
+// Message holds an error message of Metronome
+type Message struct {
+	Message string `json:"message"`
+}
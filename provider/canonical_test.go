@@ -0,0 +1,64 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/axelspringer/moppi/provider"
+)
+
+var _ = Describe("Canonical", func() {
+	It("canonicalizes the example of RFC 8785", func() {
+		doc, err := provider.CanonicalJSON([]byte(`{
+			"numbers": [333333333.33333329, 1E30, 4.50, 2e-3, 0.000000000000000000000000001],
+			"string": "\u20ac$\u000F\u000aA'\u0042\u0022\u005c\\\"\/",
+			"literals": [null, true, false]
+		}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(doc)).To(Equal(`{"literals":[null,true,false],"numbers":[333333333.3333333,1e+30,4.5,0.002,1e-27],"string":"€$\u000f\nA'B\"\\\\\"/"}`))
+	})
+
+	It("sorts the members by their UTF-16 code units", func() {
+		doc, err := provider.CanonicalJSON([]byte(`{"\u20ac":1,"\ud83d\ude00":2,"\r":3,"1":4,"\u0080":5,"\u00f6":6,"b":7,"a":{"z":[],"y":{}},"\ufb33":8}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(doc)).To(Equal("{\"\\r\":3,\"1\":4,\"a\":{\"y\":{},\"z\":[]},\"b\":7,\"\u0080\":5,\"ö\":6,\"€\":1,\"😀\":2,\"דּ\":8}"))
+	})
+
+	It("formats numbers as ECMAScript does", func() {
+		for in, out := range map[string]string{
+			"0": "0", "-0": "0", "1": "1", "-1.5": "-1.5", "1e21": "1e+21", "1e20": "100000000000000000000",
+			"0.000001": "0.000001", "1e-7": "1e-7", "9007199254740991": "9007199254740991", "1E9": "1000000000",
+		} {
+			doc, err := provider.CanonicalJSON([]byte(in))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(doc)).To(Equal(out), in)
+		}
+	})
+
+	It("rejects a number out of range", func() {
+		_, err := provider.CanonicalJSON([]byte(`[1e400]`))
+		Expect(err).To(Equal(provider.ErrNotCanonical("1e400")))
+	})
+
+	It("gives the same digest regardless of the order and whitespace of the document", func() {
+		a, err := provider.CanonicalJSON([]byte(`{"install": {"marathon": true, "chronos": false}, "marathon": []}`))
+		Expect(err).NotTo(HaveOccurred())
+		b, err := provider.CanonicalJSON([]byte(`{"marathon":[],"install":{"chronos":false,"marathon":true}}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(a).To(Equal(b))
+	})
+})
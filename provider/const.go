@@ -24,4 +24,33 @@ const (
 	MoppiChronos       = "/chronos"
 	MoppiInstall       = "/install"
 	MoppiUninstall     = "/uninstall"
+	MoppiRevisionMeta  = "/meta"
+	MoppiAudit         = "/audit"
+	MoppiWebhooks      = "/webhooks"
+	MoppiRevisions     = "/revisions"
+)
+
+const (
+	// ChangeUniverseCreated is a universe, that was created in the KV
+	ChangeUniverseCreated = "universe.created"
+	// ChangeUniverseDeleted is a universe, that was deleted in the KV
+	ChangeUniverseDeleted = "universe.deleted"
+	// ChangeRevisionAdded is a package revision, that was added in the KV
+	ChangeRevisionAdded = "revision.added"
+	// ChangeRevisionDeleted is a package revision, that was deleted in the KV
+	ChangeRevisionDeleted = "revision.deleted"
+)
+
+const (
+	// WaitDeployment waits on the Marathon apps, until their deployment is finished
+	WaitDeployment = "deployment"
+	// WaitHealthy waits on the Marathon apps, until all their instances are healthy
+	WaitHealthy = "healthy"
+)
+
+const (
+	// RevisionLatest resolves to the newest revision of a package, that is not yanked
+	RevisionLatest = "latest"
+	// DigestPrefix is prepended to the hex encoded digest of a package
+	DigestPrefix = "sha256:"
 )
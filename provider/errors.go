@@ -0,0 +1,76 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import "fmt"
+
+// ErrRevisionExists is returned when a published revision should be overwritten
+type ErrRevisionExists string
+
+// Error returns a custom error
+func (err ErrRevisionExists) Error() string {
+	return fmt.Sprintf("Revision %v is already published and cannot be changed", string(err))
+}
+
+// ErrRevisionYanked is returned when a yanked revision should be installed
+type ErrRevisionYanked string
+
+// Error returns a custom error
+func (err ErrRevisionYanked) Error() string {
+	return fmt.Sprintf("Revision %v has been yanked", string(err))
+}
+
+// ErrNoRevision is returned when a package has no installable revision
+type ErrNoRevision string
+
+// Error returns a custom error
+func (err ErrNoRevision) Error() string {
+	return fmt.Sprintf("There is no installable revision of %v", string(err))
+}
+
+// ErrDigestMismatch is returned when the content of a revision does not match its digest
+type ErrDigestMismatch string
+
+// Error returns a custom error
+func (err ErrDigestMismatch) Error() string {
+	return fmt.Sprintf("The content of revision %v does not match its digest", string(err))
+}
+
+// ErrNotSetup is returned when the repo in the KV has not been set up
+type ErrNotSetup string
+
+// Error returns a custom error
+func (err ErrNotSetup) Error() string {
+	return fmt.Sprintf("The repo at %v has not been set up", string(err))
+}
+
+// ErrVersionMismatch is returned when the version of the repo does not match moppi
+type ErrVersionMismatch struct {
+	Repo  string
+	Moppi string
+}
+
+// Error returns a custom error
+func (err ErrVersionMismatch) Error() string {
+	return fmt.Sprintf("The repo has version %v, moppi has version %v", err.Repo, err.Moppi)
+}
+
+// ErrNotCanonical is returned when a value has no canonical JSON form
+type ErrNotCanonical string
+
+// Error returns a custom error
+func (err ErrNotCanonical) Error() string {
+	return fmt.Sprintf("The value has no canonical JSON form: %v", string(err))
+}
@@ -15,8 +15,6 @@
 package etcd
 
 import (
-	"errors"
-
 	"github.com/axelspringer/moppi/provider"
 	"github.com/docker/libkv/store"
 	"github.com/docker/libkv/store/etcd"
@@ -34,14 +32,44 @@ func (p *Provider) GetPackages(req *provider.Request) (*provider.Packages, error
 	return p.Provider.GetPackages(req)
 }
 
-// DeletePackage deletes a package from the universe in the etcd
-func (p *Provider) DeletePackage(req *provider.Request) error {
-	return p.Provider.DeletePackage(req)
+// YankPackage yanks all revisions of a package in the etcd
+func (p *Provider) YankPackage(req *provider.Request) error {
+	return p.Provider.YankPackage(req)
+}
+
+// PurgePackage removes a package from the universe in the etcd
+func (p *Provider) PurgePackage(req *provider.Request) error {
+	return p.Provider.PurgePackage(req)
+}
+
+// CreatePackageRevision creates a new immutable revision of a package in the etcd
+func (p *Provider) CreatePackageRevision(req *provider.Request, pkg *provider.Package, signature string) (*int, error) {
+	return p.Provider.CreatePackageRevision(req, pkg, signature)
 }
 
-// DeletePackageRevision deletes a revision of a package in the etcd
-func (p *Provider) DeletePackageRevision(req *provider.Request) error {
-	return p.Provider.DeletePackageRevision(req) // @todo, this is happy path
+// YankPackageRevision yanks a revision of a package in the etcd
+func (p *Provider) YankPackageRevision(req *provider.Request) error {
+	return p.Provider.YankPackageRevision(req)
+}
+
+// PurgePackageRevision removes a revision of a package from the etcd
+func (p *Provider) PurgePackageRevision(req *provider.Request) error {
+	return p.Provider.PurgePackageRevision(req)
+}
+
+// GetRevision gets the meta infos of a package revision
+func (p *Provider) GetRevision(req *provider.Request) (*provider.Revision, error) {
+	return p.Provider.GetRevision(req)
+}
+
+// LatestRevision gets the newest revision of a package, that is not yanked
+func (p *Provider) LatestRevision(req *provider.Request) (string, error) {
+	return p.Provider.LatestRevision(req)
+}
+
+// GetVerifiedPackage gets a package and verifies it against its digest
+func (p *Provider) GetVerifiedPackage(req *provider.Request) (*provider.Package, *provider.Revision, error) {
+	return p.Provider.GetVerifiedPackage(req)
 }
 
 // GetRevisions gets all the packages revisions from a universe
@@ -74,14 +102,58 @@ func (p *Provider) GetUniverses() (*provider.Universes, error) {
 	return p.Provider.GetUniverses()
 }
 
+// CreateAuditEntry appends an entry to the audit log in the etcd
+func (p *Provider) CreateAuditEntry(entry *provider.AuditEntry) error {
+	return p.Provider.CreateAuditEntry(entry)
+}
+
+// GetAuditEntries gets all entries of the audit log in the etcd
+func (p *Provider) GetAuditEntries() ([]*provider.AuditEntry, error) {
+	return p.Provider.GetAuditEntries()
+}
+
+// DeleteAuditEntry removes an entry from the audit log in the etcd
+func (p *Provider) DeleteAuditEntry(id string) error {
+	return p.Provider.DeleteAuditEntry(id)
+}
+
+// CreateWebhook stores a webhook in etcd
+func (p *Provider) CreateWebhook(webhook *provider.Webhook) error {
+	return p.Provider.CreateWebhook(webhook)
+}
+
+// GetWebhooks returns all webhooks from etcd
+func (p *Provider) GetWebhooks() ([]*provider.Webhook, error) {
+	return p.Provider.GetWebhooks()
+}
+
+// GetWebhook returns a webhook from etcd
+func (p *Provider) GetWebhook(id string) (*provider.Webhook, error) {
+	return p.Provider.GetWebhook(id)
+}
+
+// DeleteWebhook removes a webhook from etcd
+func (p *Provider) DeleteWebhook(id string) error {
+	return p.Provider.DeleteWebhook(id)
+}
+
 // CheckVersion checks the meta version of the moppi repo
 func (p *Provider) CheckVersion(moppiVersion string) (bool, error) {
 	version, err := p.Version()
 	if err != nil {
 		return false, err
 	}
-	// TODO: real error
-	return string(version.Value) == moppiVersion, errors.New("Version not matching")
+
+	if string(version.Value) != moppiVersion {
+		return false, provider.ErrVersionMismatch{Repo: string(version.Value), Moppi: moppiVersion}
+	}
+
+	return true, nil
+}
+
+// IsSetup checks that the moppi repo has been setup in etcd
+func (p *Provider) IsSetup() (bool, error) {
+	return p.Provider.IsSetup()
 }
 
 // Setup checks for the setup of the moppi repo
@@ -89,6 +161,16 @@ func (p *Provider) Setup() (bool, error) {
 	return p.Provider.Setup()
 }
 
+// MigrateDigests stores the digests of the revisions, that were published without one
+func (p *Provider) MigrateDigests() error {
+	return p.Provider.MigrateDigests()
+}
+
+// Close closes the client of etcd
+func (p *Provider) Close() {
+	p.Provider.Close()
+}
+
 // CreateStore creates the etcd store
 func (p *Provider) CreateStore(bucket string) (store.Store, error) {
 	p.SetStoreType(store.ETCD)
@@ -96,3 +178,8 @@ func (p *Provider) CreateStore(bucket string) (store.Store, error) {
 
 	return p.Provider.CreateStore(bucket)
 }
+
+// Watch watches the universes in etcd for changes
+func (p *Provider) Watch(stop <-chan struct{}) <-chan provider.Change {
+	return p.Provider.Watch(stop)
+}
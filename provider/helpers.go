@@ -13,3 +13,33 @@
 // limitations under the License.
 
 package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// Canonical returns the canonical document of a package, which is the base
+// for its digest and signature. It is the canonical JSON (RFC 8785) of the
+// package, as it is decoded from its document
+func Canonical(pkg *Package) ([]byte, error) {
+	doc, err := json.Marshal(pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	return CanonicalJSON(doc)
+}
+
+// Digest computes the SHA-256 digest of the canonical package document
+func Digest(pkg *Package) (string, error) {
+	doc, err := Canonical(pkg)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(doc)
+
+	return DigestPrefix + hex.EncodeToString(sum[:]), nil
+}
@@ -18,4 +18,14 @@ import "time"
 
 const (
 	connectionTimeout = 30 * time.Second
+	watchBuffer       = 64
+	watchRetry        = time.Second
+	watchMaxRetry     = time.Minute
+	// watchQuiet is the pause of the notifications, after which the tree is listed
+	watchQuiet = 250 * time.Millisecond
+	// watchMaxQuiet is the longest a burst of notifications defers the listing
+	watchMaxQuiet = 2 * time.Second
+	// claimAttempts is the number of revision numbers tried by a publish,
+	// before it gives up on revisions, that were claimed in between
+	claimAttempts = 5
 )
@@ -15,8 +15,12 @@
 package kv
 
 import (
+	"sort"
+	"strconv"
 	"strings"
 
+	"github.com/docker/libkv/store"
+
 	"github.com/axelspringer/moppi/provider"
 )
 
@@ -62,3 +66,49 @@ func universePkgBasePath(prefix string, universe string, pkg string) string {
 func universePkgPath(prefix string, universe string, pkg string, rev string) string {
 	return universePkgBasePath(prefix, universe, pkg) + leadingSlash(rev)
 }
+
+// universePkgRevMetaPath gets the meta path of a package revision
+func universePkgRevMetaPath(prefix string, universe string, pkg string, rev string) string {
+	return universePkgPath(prefix, universe, pkg, rev) + provider.MoppiRevisionMeta
+}
+
+// revisionCounterPath gets the path of the last revision number of a package,
+// it is kept apart from the package, so that a purge does not reset it
+func revisionCounterPath(prefix string, universe string, pkg string) string {
+	return prefix + provider.MoppiRevisions + leadingSlash(universe) + leadingSlash(pkg)
+}
+
+// auditPath gets the path of the audit log or of an entry
+func auditPath(prefix string, id string) string {
+	if id == "" {
+		return prefix + provider.MoppiAudit
+	}
+	return prefix + provider.MoppiAudit + leadingSlash(id)
+}
+
+// webhookPath gets the path of the webhooks or of a webhook
+func webhookPath(prefix string, id string) string {
+	if id == "" {
+		return prefix + provider.MoppiWebhooks
+	}
+	return prefix + provider.MoppiWebhooks + leadingSlash(id)
+}
+
+// revisionNumbers returns the sorted revision numbers of listed revisions
+func revisionNumbers(kvRevisions []*store.KVPair) []int {
+	revs := make([]int, 0, len(kvRevisions))
+
+	for _, kvRevision := range kvRevisions {
+		path := strings.Split(strings.TrimSuffix(kvRevision.Key, "/"), "/")
+
+		rev, err := strconv.Atoi(path[len(path)-1])
+		if err != nil {
+			continue // not a revision
+		}
+
+		revs = append(revs, rev)
+	}
+	sort.Ints(revs)
+
+	return revs
+}
@@ -17,13 +17,17 @@ package kv
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/axelspringer/moppi/provider"
+	"github.com/axelspringer/moppi/version"
 	"github.com/docker/libkv"
 	"github.com/docker/libkv/store"
 	"github.com/katallaxie/kvstructure"
@@ -35,9 +39,9 @@ func (p *Provider) SetStoreType(storeType store.Backend) {
 	p.storeType = storeType
 }
 
-// SetKVClient kvClient setter
+// SetKVClient kvClient setter, the operations on the client are observed
 func (p *Provider) SetKVClient(kvClient store.Store) {
-	p.kvClient = kvClient
+	p.kvClient = &instrumented{kvClient}
 }
 
 // Version returns the version of the
@@ -51,12 +55,12 @@ func (p *Provider) Setup() (bool, error) {
 	if ok, _ := p.kvClient.Exists(p.Prefix); !ok {
 
 		// create config
-		// meta := provider.Meta{
-		// 	Version: version.Version,
-		// }
+		meta := provider.Meta{
+			Version: version.Version,
+		}
 
 		cfg := &provider.Config{
-		// Meta: &meta,
+			Meta: &meta,
 		}
 
 		// Transcode
@@ -65,7 +69,7 @@ func (p *Provider) Setup() (bool, error) {
 		}
 
 		// create structure
-		for _, v := range []string{provider.MoppiUniverses, provider.MoppiPackages} {
+		for _, v := range []string{provider.MoppiUniverses, provider.MoppiPackages, provider.MoppiAudit, provider.MoppiWebhooks, provider.MoppiRevisions} {
 			if err := p.kvClient.Put(p.Prefix+v, []byte(""), &store.WriteOptions{IsDir: true}); err != nil {
 				return false, err
 			}
@@ -75,6 +79,11 @@ func (p *Provider) Setup() (bool, error) {
 	return true, nil
 }
 
+// IsSetup checks that moppi has been setup in the kv
+func (p *Provider) IsSetup() (bool, error) {
+	return p.kvClient.Exists(p.Prefix + provider.MoppiUniverses)
+}
+
 // GetPackage return a package
 func (p *Provider) GetPackage(req *provider.Request) (*provider.Package, error) {
 	path := universePkgPath(p.Prefix, req.Universe, req.Name, req.Revision)
@@ -139,59 +148,321 @@ func (p *Provider) DeleteUniverse(req *provider.Request) error {
 	return p.kvClient.DeleteTree(path)
 }
 
-// DeletePackage deletes a package form a universe
-func (p *Provider) DeletePackage(req *provider.Request) error {
+// YankPackage yanks all revisions of a package in a universe
+func (p *Provider) YankPackage(req *provider.Request) error {
+	path := trailingSlash(universePkgBasePath(p.Prefix, req.Universe, req.Name))
+
+	kvRevisions, err := p.kvClient.List(path)
+	if err != nil {
+		return err
+	}
+
+	for _, rev := range revisionNumbers(kvRevisions) {
+		revReq := *req
+		revReq.Revision = strconv.Itoa(rev)
+
+		if err := p.YankPackageRevision(&revReq); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PurgePackage removes a package with all its revisions from a universe
+func (p *Provider) PurgePackage(req *provider.Request) error {
 	path := universePkgBasePath(p.Prefix, req.Universe, req.Name)
 
 	return p.kvClient.DeleteTree(path)
 }
 
-// CreatePackageRevision creates a new package revision in a universe
-// and returns the new revision and any error
-func (p *Provider) CreatePackageRevision(req *provider.Request, pkg *provider.Package) (*int, error) {
-	// check if package exists
-	path := universePkgBasePath(p.Prefix, req.Universe, req.Name)
-	rev := 1
+// nextRevision counts up the last revision number of a package and returns it.
+// Numbers are never handed out twice, also not after a revision has been purged.
+// Packages, that were published before the counter, continue after their newest revision
+func (p *Provider) nextRevision(req *provider.Request) (int, error) {
+	path := revisionCounterPath(p.Prefix, req.Universe, req.Name)
 
-	// if not exists, create package
-	if ok, _ := p.kvClient.Exists(path); !ok {
-		// create package in universe
-		// @todo check if universe exists
-		revPath := universePkgPath(p.Prefix, req.Universe, req.Name, fmt.Sprint(rev))
+	for {
+		last := 0
 
-		// Transcode
-		if err := kvstructure.Transcode(&pkg, revPath, p.kvClient); err != nil {
-			return nil, err
+		previous, err := p.kvClient.Get(path)
+		switch {
+		case err == store.ErrKeyNotFound:
+			previous = nil
+
+			kvRevisions, err := p.kvClient.List(trailingSlash(universePkgBasePath(p.Prefix, req.Universe, req.Name)))
+			if err != nil && err != store.ErrKeyNotFound {
+				return 0, err
+			}
+
+			if revs := revisionNumbers(kvRevisions); len(revs) > 0 {
+				last = revs[len(revs)-1]
+			}
+		case err != nil:
+			return 0, err
+		default:
+			if last, err = strconv.Atoi(string(previous.Value)); err != nil {
+				return 0, err
+			}
 		}
 
-		return &rev, nil
+		next := last + 1
+		if _, _, err := p.kvClient.AtomicPut(path, []byte(strconv.Itoa(next)), previous, nil); err != nil {
+			if err == store.ErrKeyExists || err == store.ErrKeyModified {
+				continue // counted up in between
+			}
+			return 0, err
+		}
+
+		return next, nil
 	}
+}
 
-	// get all revisions
-	revs, err := p.kvClient.List(path) // is sorted from etcd
+// CreatePackageRevision creates a new package revision in a universe
+// and returns the new revision and any error. Published revisions
+// are immutable, the digest and the signature of the package are stored along with it.
+func (p *Provider) CreatePackageRevision(req *provider.Request, pkg *provider.Package, signature string) (*int, error) {
+	digest, err := provider.Digest(pkg)
 	if err != nil {
 		return nil, err
 	}
 
-	kvRevPath := strings.Split(string(revs[len(revs)-1].Key), "/")
-	kvRev := kvRevPath[len(kvRevPath)-1]
+	// claim the revision, the next number is tried, if it has been published before
+	var rev int
+	var metaPath string
+	for attempt := 1; ; attempt++ {
+		if rev, err = p.nextRevision(req); err != nil {
+			return nil, err
+		}
+
+		metaPath = universePkgRevMetaPath(p.Prefix, req.Universe, req.Name, strconv.Itoa(rev))
+		_, _, err = p.kvClient.AtomicPut(metaPath+"/digest", []byte(digest), nil, nil)
+		if err == nil {
+			break
+		}
+
+		if err != store.ErrKeyExists {
+			return nil, err
+		}
+
+		if attempt == claimAttempts {
+			return nil, provider.ErrRevisionExists(strconv.Itoa(rev))
+		}
+	}
+
+	// Transcode, the revision is complete when it has been created
+	revPath := universePkgPath(p.Prefix, req.Universe, req.Name, strconv.Itoa(rev))
+	meta := &provider.Revision{
+		Digest:    digest,
+		Created:   time.Now().UTC().Format(time.RFC3339),
+		Signature: signature,
+	}
+
+	err = kvstructure.Transcode(&pkg, revPath, p.kvClient)
+	if err == nil {
+		err = kvstructure.Transcode(&meta, metaPath, p.kvClient)
+	}
 
-	rev, err = strconv.Atoi(kvRev)
 	if err != nil {
+		// release the claim, along with what has been written
+		if cleanupErr := p.kvClient.DeleteTree(revPath); cleanupErr != nil {
+			log.Warnf("Could not clean up the failed revision %v: %v", revPath, cleanupErr)
+		}
 		return nil, err
 	}
-	revPath := universePkgPath(p.Prefix, req.Universe, req.Name, fmt.Sprint(rev+1))
 
-	// Transcode
-	if err := kvstructure.Transcode(&pkg, revPath, p.kvClient); err != nil {
-		return nil, err
+	return &rev, nil
+}
+
+// GetRevision returns the meta infos of a package revision
+func (p *Provider) GetRevision(req *provider.Request) (*provider.Revision, error) {
+	path := universePkgRevMetaPath(p.Prefix, req.Universe, req.Name, req.Revision)
+	var rev provider.Revision
+
+	if err := kvstructure.Transdecode(&rev, path, p.kvClient); err != nil {
+		return &rev, err
 	}
 
 	return &rev, nil
 }
 
-// DeletePackageRevision deletes a package revision from a universe
-func (p *Provider) DeletePackageRevision(req *provider.Request) error {
+// MigrateDigests stores the digests of the revisions, that were published
+// before revisions had digests. The digest is taken from the content in the KV,
+// so it is only run by an admin (e.g. moppi setup) and never on a read
+func (p *Provider) MigrateDigests() error {
+	universes, err := p.list(trailingSlash(universesPath(p.Prefix)))
+	if err != nil {
+		return err
+	}
+
+	for _, universe := range universes {
+		pkgs, err := p.list(trailingSlash(universePath(p.Prefix, universe) + provider.MoppiPackages))
+		if err != nil {
+			return err
+		}
+
+		for _, pkg := range pkgs {
+			kvRevisions, err := p.kvClient.List(trailingSlash(universePkgBasePath(p.Prefix, universe, pkg)))
+			if err != nil {
+				return err
+			}
+
+			for _, rev := range revisionNumbers(kvRevisions) {
+				req := &provider.Request{Universe: universe, Name: pkg, Revision: strconv.Itoa(rev)}
+
+				if err := p.migrateDigest(req); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// migrateDigest stores the digest of a revision from its content, if it has none
+func (p *Provider) migrateDigest(req *provider.Request) error {
+	meta, err := p.GetRevision(req)
+	if err != nil && err != store.ErrKeyNotFound {
+		return err
+	}
+
+	if meta.Digest != "" {
+		return nil
+	}
+
+	pkg, err := p.GetPackage(req)
+	if err != nil {
+		return err
+	}
+
+	digest, err := provider.Digest(pkg)
+	if err != nil {
+		return err
+	}
+
+	// claim the digest, another publish may have stored it already
+	metaPath := universePkgRevMetaPath(p.Prefix, req.Universe, req.Name, req.Revision)
+	previous, err := p.kvClient.Get(metaPath + "/digest")
+	if err != nil && err != store.ErrKeyNotFound {
+		return err
+	}
+	if previous != nil && len(previous.Value) > 0 {
+		return nil
+	}
+
+	if _, _, err := p.kvClient.AtomicPut(metaPath+"/digest", []byte(digest), previous, nil); err != nil {
+		if err == store.ErrKeyExists || err == store.ErrKeyModified {
+			return nil
+		}
+		return err
+	}
+
+	meta.Digest = digest
+	if meta.Created == "" {
+		meta.Created = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	if err := kvstructure.Transcode(&meta, metaPath, p.kvClient); err != nil {
+		return err
+	}
+	log.Infof("Migrated the digest of the revision %v", metaPath)
+
+	return nil
+}
+
+// LatestRevision returns the newest revision of a package, that is not yanked.
+// Revisions, that are still being published, are skipped
+func (p *Provider) LatestRevision(req *provider.Request) (string, error) {
+	path := trailingSlash(universePkgBasePath(p.Prefix, req.Universe, req.Name))
+
+	kvRevisions, err := p.kvClient.List(path)
+	if err != nil {
+		return "", err
+	}
+
+	revs := revisionNumbers(kvRevisions)
+	for i := len(revs) - 1; i >= 0; i-- {
+		revReq := *req
+		revReq.Revision = strconv.Itoa(revs[i])
+
+		meta, err := p.GetRevision(&revReq)
+		if err != nil && err != store.ErrKeyNotFound {
+			return "", err
+		}
+
+		// a revision without a digest is not trusted
+		if meta.Digest == "" {
+			return "", provider.ErrDigestMismatch(revReq.Revision)
+		}
+
+		if meta.Created != "" && !meta.Yanked {
+			return revReq.Revision, nil
+		}
+	}
+
+	return "", provider.ErrNoRevision(req.Name)
+}
+
+// GetVerifiedPackage returns a package revision after verifying
+// its content against the stored digest. An empty or latest revision
+// is resolved and set on the request.
+func (p *Provider) GetVerifiedPackage(req *provider.Request) (*provider.Package, *provider.Revision, error) {
+	if req.Revision == "" || req.Revision == provider.RevisionLatest {
+		rev, err := p.LatestRevision(req)
+		if err != nil {
+			return nil, nil, err
+		}
+		req.Revision = rev
+	}
+
+	meta, err := p.GetRevision(req)
+	if err != nil && err != store.ErrKeyNotFound {
+		return nil, nil, err
+	}
+
+	if meta.Digest == "" {
+		return nil, nil, provider.ErrDigestMismatch(req.Revision)
+	}
+
+	pkg, err := p.GetPackage(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	digest, err := provider.Digest(pkg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if digest != meta.Digest {
+		return nil, nil, provider.ErrDigestMismatch(req.Revision)
+	}
+
+	return pkg, meta, nil
+}
+
+// YankPackageRevision yanks a package revision in a universe. Yanked revisions
+// are kept, but are not resolved as latest and cannot be installed
+func (p *Provider) YankPackageRevision(req *provider.Request) error {
+	meta, err := p.GetRevision(req)
+	if err != nil && err != store.ErrKeyNotFound {
+		return err
+	}
+
+	if meta.Yanked {
+		return nil // noop
+	}
+
+	meta.Yanked = true
+	meta.YankedAt = time.Now().UTC().Format(time.RFC3339)
+	path := universePkgRevMetaPath(p.Prefix, req.Universe, req.Name, req.Revision)
+
+	return kvstructure.Transcode(&meta, path, p.kvClient)
+}
+
+// PurgePackageRevision removes a package revision from a universe
+func (p *Provider) PurgePackageRevision(req *provider.Request) error {
 	path := universePkgPath(p.Prefix, req.Universe, req.Name, req.Revision)
 
 	return p.kvClient.DeleteTree(path)
@@ -236,6 +507,110 @@ func (p *Provider) GetUniverse(req *provider.Request) (*provider.Universe, error
 	return &universe, nil
 }
 
+// CreateAuditEntry appends an entry to the audit log
+func (p *Provider) CreateAuditEntry(entry *provider.AuditEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	return p.kvClient.Put(auditPath(p.Prefix, entry.ID), data, nil)
+}
+
+// GetAuditEntries returns all entries of the audit log, ordered by their id
+func (p *Provider) GetAuditEntries() ([]*provider.AuditEntry, error) {
+	entries := make([]*provider.AuditEntry, 0)
+
+	kvEntries, err := p.kvClient.List(trailingSlash(auditPath(p.Prefix, "")))
+	if err == store.ErrKeyNotFound {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, kvEntry := range kvEntries {
+		var entry provider.AuditEntry
+		if err := json.Unmarshal(kvEntry.Value, &entry); err != nil {
+			return nil, err
+		}
+		entries = append(entries, &entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ID < entries[j].ID
+	})
+
+	return entries, nil
+}
+
+// DeleteAuditEntry removes an entry from the audit log
+func (p *Provider) DeleteAuditEntry(id string) error {
+	return p.kvClient.Delete(auditPath(p.Prefix, id))
+}
+
+// CreateWebhook stores a webhook
+func (p *Provider) CreateWebhook(webhook *provider.Webhook) error {
+	data, err := json.Marshal(webhook)
+	if err != nil {
+		return err
+	}
+
+	return p.kvClient.Put(webhookPath(p.Prefix, webhook.ID), data, nil)
+}
+
+// GetWebhooks returns all webhooks
+func (p *Provider) GetWebhooks() ([]*provider.Webhook, error) {
+	webhooks := make([]*provider.Webhook, 0)
+
+	kvWebhooks, err := p.kvClient.List(trailingSlash(webhookPath(p.Prefix, "")))
+	if err == store.ErrKeyNotFound {
+		return webhooks, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, kvWebhook := range kvWebhooks {
+		var webhook provider.Webhook
+		if err := json.Unmarshal(kvWebhook.Value, &webhook); err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, &webhook)
+	}
+
+	sort.Slice(webhooks, func(i, j int) bool {
+		return webhooks[i].Created.Before(webhooks[j].Created)
+	})
+
+	return webhooks, nil
+}
+
+// GetWebhook returns a webhook
+func (p *Provider) GetWebhook(id string) (*provider.Webhook, error) {
+	kvWebhook, err := p.kvClient.Get(webhookPath(p.Prefix, id))
+	if err != nil {
+		return nil, err
+	}
+
+	var webhook provider.Webhook
+	if err := json.Unmarshal(kvWebhook.Value, &webhook); err != nil {
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+// DeleteWebhook removes a webhook
+func (p *Provider) DeleteWebhook(id string) error {
+	return p.kvClient.Delete(webhookPath(p.Prefix, id))
+}
+
+// Close closes the client of the kv
+func (p *Provider) Close() {
+	p.kvClient.Close()
+}
+
 // CreateStore creates the K/V store
 func (p *Provider) CreateStore(bucket string) (store.Store, error) {
 	storeConfig := &store.Config{
@@ -0,0 +1,13 @@
+package kv_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestKV(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "KV Suite")
+}
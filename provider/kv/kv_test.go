@@ -0,0 +1,220 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kv_test
+
+import (
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/axelspringer/moppi/provider"
+	"github.com/axelspringer/moppi/provider/kv"
+	"github.com/docker/libkv/store"
+	"github.com/katallaxie/kvstructure"
+)
+
+const revisionsPath = "moppi/universes/prod/packages/nginx"
+
+var _ = Describe("Provider", func() {
+	var (
+		kvStore *memory
+		p       *kv.Provider
+		req     *provider.Request
+		pkg     *provider.Package
+	)
+
+	BeforeEach(func() {
+		kvStore = newMemory()
+		p = &kv.Provider{Prefix: "moppi"}
+		p.SetKVClient(kvStore)
+
+		req = &provider.Request{Universe: "prod", Name: "nginx"}
+		pkg = &provider.Package{Install: provider.Install{Marathon: true}}
+	})
+
+	It("publishes revisions and verifies them", func() {
+		rev, err := p.CreatePackageRevision(req, pkg, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*rev).To(Equal(1))
+
+		rev, err = p.CreatePackageRevision(req, pkg, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*rev).To(Equal(2))
+
+		_, meta, err := p.GetVerifiedPackage(req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(req.Revision).To(Equal("2"))
+
+		digest, _ := provider.Digest(pkg)
+		Expect(meta.Digest).To(Equal(digest))
+	})
+
+	It("does not overwrite a revision, that was claimed in between", func() {
+		kvStore.hook = func(op string, key string) error {
+			if op == "atomicput" && key == revisionsPath+"/1/meta/digest" {
+				kvStore.hook = nil
+				kvStore.Put(key, []byte("sha256:other"), nil)
+			}
+			return nil
+		}
+
+		rev, err := p.CreatePackageRevision(req, pkg, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*rev).To(Equal(2))
+
+		pair, err := kvStore.Get(revisionsPath + "/1/meta/digest")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(pair.Value)).To(Equal("sha256:other"))
+	})
+
+	It("gives up, when every revision number was claimed in between", func() {
+		kvStore.hook = func(op string, key string) error {
+			if op == "atomicput" && strings.HasSuffix(key, "/meta/digest") {
+				return store.ErrKeyExists
+			}
+			return nil
+		}
+
+		_, err := p.CreatePackageRevision(req, pkg, "")
+		Expect(err).To(Equal(provider.ErrRevisionExists("5")))
+	})
+
+	It("does not reuse the number of a purged revision", func() {
+		_, err := p.CreatePackageRevision(req, pkg, "")
+		Expect(err).NotTo(HaveOccurred())
+
+		req.Revision = "1"
+		Expect(p.PurgePackageRevision(req)).To(Succeed())
+		Expect(p.PurgePackage(req)).To(Succeed())
+
+		req.Revision = ""
+		rev, err := p.CreatePackageRevision(req, pkg, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*rev).To(Equal(2))
+	})
+
+	It("continues after the newest revision of a package, that was published before the counter", func() {
+		Expect(kvstructure.Transcode(&pkg, revisionsPath+"/3", kvStore)).To(Succeed())
+
+		rev, err := p.CreatePackageRevision(req, pkg, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*rev).To(Equal(4))
+	})
+
+	It("releases the claim of a revision, that could not be written", func() {
+		kvStore.hook = func(op string, key string) error {
+			if op == "put" && strings.HasPrefix(key, revisionsPath+"/1/meta/") {
+				return errors.New("etcd is gone")
+			}
+			return nil
+		}
+
+		_, err := p.CreatePackageRevision(req, pkg, "")
+		Expect(err).To(MatchError("etcd is gone"))
+
+		ok, _ := kvStore.Exists(revisionsPath + "/1")
+		Expect(ok).To(BeFalse())
+
+		kvStore.hook = nil
+		rev, err := p.CreatePackageRevision(req, pkg, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*rev).To(Equal(2))
+	})
+
+	It("skips a revision, that is still being published", func() {
+		_, err := p.CreatePackageRevision(req, pkg, "")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, _, err = kvStore.AtomicPut(revisionsPath+"/2/meta/digest", []byte("sha256:pending"), nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		rev, err := p.LatestRevision(req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rev).To(Equal("1"))
+	})
+
+	It("rejects a revision, that has no digest", func() {
+		Expect(kvstructure.Transcode(&pkg, revisionsPath+"/1", kvStore)).To(Succeed())
+
+		_, _, err := p.GetVerifiedPackage(req)
+		Expect(err).To(Equal(provider.ErrDigestMismatch("1")))
+
+		req.Revision = "1"
+		_, _, err = p.GetVerifiedPackage(req)
+		Expect(err).To(Equal(provider.ErrDigestMismatch("1")))
+
+		ok, _ := kvStore.Exists(revisionsPath + "/1/meta/digest")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("rejects a revision, whose digest was deleted", func() {
+		_, err := p.CreatePackageRevision(req, pkg, "")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(kvStore.Put(revisionsPath+"/1/meta/digest", []byte(""), nil)).To(Succeed())
+
+		req.Revision = "1"
+		_, _, err = p.GetVerifiedPackage(req)
+		Expect(err).To(Equal(provider.ErrDigestMismatch("1")))
+	})
+
+	It("migrates the digests of the revisions, that were published without one", func() {
+		Expect(kvstructure.Transcode(&pkg, revisionsPath+"/1", kvStore)).To(Succeed())
+		Expect(p.MigrateDigests()).To(Succeed())
+
+		_, meta, err := p.GetVerifiedPackage(req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(req.Revision).To(Equal("1"))
+
+		digest, _ := provider.Digest(pkg)
+		Expect(meta.Digest).To(Equal(digest))
+		Expect(meta.Created).NotTo(BeEmpty())
+	})
+
+	It("rejects a revision, whose content does not match its digest", func() {
+		_, err := p.CreatePackageRevision(req, pkg, "")
+		Expect(err).NotTo(HaveOccurred())
+
+		tampered := &provider.Package{Install: provider.Install{Chronos: true}}
+		Expect(kvstructure.Transcode(&tampered, revisionsPath+"/1", kvStore)).To(Succeed())
+
+		req.Revision = "1"
+		_, _, err = p.GetVerifiedPackage(req)
+		Expect(err).To(Equal(provider.ErrDigestMismatch("1")))
+	})
+
+	It("does not resolve a yanked revision", func() {
+		_, err := p.CreatePackageRevision(req, pkg, "")
+		Expect(err).NotTo(HaveOccurred())
+
+		req.Revision = "1"
+		Expect(p.YankPackageRevision(req)).To(Succeed())
+
+		req.Revision = ""
+		_, err = p.LatestRevision(req)
+		Expect(err).To(Equal(provider.ErrNoRevision("nginx")))
+	})
+
+	It("fails on a broken KV", func() {
+		kvStore.hook = func(op string, key string) error {
+			return store.ErrKeyModified
+		}
+
+		_, _, err := p.GetVerifiedPackage(req)
+		Expect(err).To(HaveOccurred())
+	})
+})
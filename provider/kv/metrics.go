@@ -0,0 +1,103 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kv
+
+import (
+	"time"
+
+	"github.com/axelspringer/moppi/metrics"
+	"github.com/docker/libkv/store"
+)
+
+// Put observes putting a key
+func (i *instrumented) Put(key string, value []byte, options *store.WriteOptions) (err error) {
+	defer func(start time.Time) {
+		observe("put", start, err)
+	}(time.Now())
+
+	return i.Store.Put(key, value, options)
+}
+
+// Get observes getting a key
+func (i *instrumented) Get(key string) (pair *store.KVPair, err error) {
+	defer func(start time.Time) {
+		observe("get", start, err)
+	}(time.Now())
+
+	return i.Store.Get(key)
+}
+
+// Delete observes deleting a key
+func (i *instrumented) Delete(key string) (err error) {
+	defer func(start time.Time) {
+		observe("delete", start, err)
+	}(time.Now())
+
+	return i.Store.Delete(key)
+}
+
+// Exists observes checking for a key
+func (i *instrumented) Exists(key string) (ok bool, err error) {
+	defer func(start time.Time) {
+		observe("exists", start, err)
+	}(time.Now())
+
+	return i.Store.Exists(key)
+}
+
+// List observes listing a directory
+func (i *instrumented) List(directory string) (pairs []*store.KVPair, err error) {
+	defer func(start time.Time) {
+		observe("list", start, err)
+	}(time.Now())
+
+	return i.Store.List(directory)
+}
+
+// DeleteTree observes deleting a directory
+func (i *instrumented) DeleteTree(directory string) (err error) {
+	defer func(start time.Time) {
+		observe("delete_tree", start, err)
+	}(time.Now())
+
+	return i.Store.DeleteTree(directory)
+}
+
+// AtomicPut observes putting a key atomically
+func (i *instrumented) AtomicPut(key string, value []byte, previous *store.KVPair, options *store.WriteOptions) (ok bool, pair *store.KVPair, err error) {
+	defer func(start time.Time) {
+		observe("atomic_put", start, err)
+	}(time.Now())
+
+	return i.Store.AtomicPut(key, value, previous, options)
+}
+
+// AtomicDelete observes deleting a key atomically
+func (i *instrumented) AtomicDelete(key string, previous *store.KVPair) (ok bool, err error) {
+	defer func(start time.Time) {
+		observe("atomic_delete", start, err)
+	}(time.Now())
+
+	return i.Store.AtomicDelete(key, previous)
+}
+
+// observe observes an operation on the KV, a missing key is no error of the KV
+func observe(operation string, start time.Time, err error) {
+	if err == store.ErrKeyNotFound {
+		err = nil
+	}
+
+	metrics.ObserveKV(operation, start, err)
+}
@@ -0,0 +1,289 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kv_test
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/docker/libkv/store"
+)
+
+// memory is a store.Store in memory, the hook runs before
+// every operation, and fails it when it returns an error
+type memory struct {
+	pairs    map[string]*store.KVPair
+	index    uint64
+	hook     func(op string, key string) error
+	watchers []chan []*store.KVPair
+	mu       sync.Mutex
+}
+
+// newMemory returns a new empty store
+func newMemory() *memory {
+	return &memory{pairs: make(map[string]*store.KVPair)}
+}
+
+// normalize strips the slashes of a key
+func normalize(key string) string {
+	return strings.Trim(key, "/")
+}
+
+func (m *memory) before(op string, key string) error {
+	m.mu.Lock()
+	hook := m.hook
+	m.mu.Unlock()
+
+	if hook == nil {
+		return nil
+	}
+
+	return hook(op, normalize(key))
+}
+
+// changed notifies the watchers, the lock is held
+func (m *memory) changed() {
+	for _, watcher := range m.watchers {
+		select {
+		case watcher <- nil:
+		default:
+		}
+	}
+}
+
+func (m *memory) Put(key string, value []byte, options *store.WriteOptions) error {
+	if err := m.before("put", key); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.index++
+	m.pairs[normalize(key)] = &store.KVPair{Key: normalize(key), Value: value, LastIndex: m.index}
+	m.changed()
+
+	return nil
+}
+
+func (m *memory) Get(key string) (*store.KVPair, error) {
+	if err := m.before("get", key); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	pair, ok := m.pairs[normalize(key)]
+	if !ok {
+		return nil, store.ErrKeyNotFound
+	}
+
+	return pair, nil
+}
+
+func (m *memory) Delete(key string) error {
+	if err := m.before("delete", key); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.pairs, normalize(key))
+	m.changed()
+
+	return nil
+}
+
+func (m *memory) Exists(key string) (bool, error) {
+	if err := m.before("exists", key); err != nil {
+		return false, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.exists(normalize(key)), nil
+}
+
+// exists checks for a key or a directory, the lock is held
+func (m *memory) exists(key string) bool {
+	if _, ok := m.pairs[key]; ok {
+		return true
+	}
+
+	for k := range m.pairs {
+		if strings.HasPrefix(k, key+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *memory) Watch(key string, stopCh <-chan struct{}) (<-chan *store.KVPair, error) {
+	return nil, store.ErrKeyNotFound
+}
+
+func (m *memory) WatchTree(directory string, stopCh <-chan struct{}) (<-chan []*store.KVPair, error) {
+	if err := m.before("watch", directory); err != nil {
+		return nil, err
+	}
+
+	// every change is notified, as etcd does
+	notify := make(chan []*store.KVPair, 1024)
+	notify <- nil // the first notification is the current tree
+
+	m.mu.Lock()
+	m.watchers = append(m.watchers, notify)
+	m.mu.Unlock()
+
+	watch := make(chan []*store.KVPair)
+	go func() {
+		defer close(watch)
+
+		for {
+			select {
+			case <-notify:
+			case <-stopCh:
+				return
+			}
+
+			pairs, _ := m.List(directory)
+			select {
+			case watch <- pairs:
+			case <-stopCh:
+				return
+			}
+		}
+	}()
+
+	return watch, nil
+}
+
+func (m *memory) NewLock(key string, options *store.LockOptions) (store.Locker, error) {
+	return nil, store.ErrKeyNotFound
+}
+
+func (m *memory) List(directory string) ([]*store.KVPair, error) {
+	if err := m.before("list", directory); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	dir := normalize(directory)
+	if !m.exists(dir) {
+		return nil, store.ErrKeyNotFound
+	}
+
+	children := make(map[string]*store.KVPair)
+	for k, pair := range m.pairs {
+		if !strings.HasPrefix(k, dir+"/") {
+			continue
+		}
+
+		child := strings.SplitN(strings.TrimPrefix(k, dir+"/"), "/", 2)[0]
+		if child == "" {
+			continue
+		}
+
+		if k == dir+"/"+child {
+			children[child] = pair
+		} else if _, ok := children[child]; !ok {
+			children[child] = &store.KVPair{Key: dir + "/" + child}
+		}
+	}
+
+	pairs := make([]*store.KVPair, 0, len(children))
+	for _, pair := range children {
+		pairs = append(pairs, pair)
+	}
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].Key < pairs[j].Key })
+
+	return pairs, nil
+}
+
+func (m *memory) DeleteTree(directory string) error {
+	if err := m.before("deletetree", directory); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	dir := normalize(directory)
+	for k := range m.pairs {
+		if k == dir || strings.HasPrefix(k, dir+"/") {
+			delete(m.pairs, k)
+		}
+	}
+	m.changed()
+
+	return nil
+}
+
+func (m *memory) AtomicPut(key string, value []byte, previous *store.KVPair, options *store.WriteOptions) (bool, *store.KVPair, error) {
+	if err := m.before("atomicput", key); err != nil {
+		return false, nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current, ok := m.pairs[normalize(key)]
+	switch {
+	case previous == nil && ok:
+		return false, nil, store.ErrKeyExists
+	case previous != nil && !ok:
+		return false, nil, store.ErrKeyNotFound
+	case previous != nil && previous.LastIndex != current.LastIndex:
+		return false, nil, store.ErrKeyModified
+	}
+
+	m.index++
+	pair := &store.KVPair{Key: normalize(key), Value: value, LastIndex: m.index}
+	m.pairs[pair.Key] = pair
+	m.changed()
+
+	return true, pair, nil
+}
+
+func (m *memory) AtomicDelete(key string, previous *store.KVPair) (bool, error) {
+	if err := m.before("atomicdelete", key); err != nil {
+		return false, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current, ok := m.pairs[normalize(key)]
+	if !ok {
+		return false, store.ErrKeyNotFound
+	}
+	if previous != nil && previous.LastIndex != current.LastIndex {
+		return false, store.ErrKeyModified
+	}
+
+	delete(m.pairs, normalize(key))
+	m.changed()
+
+	return true, nil
+}
+
+func (m *memory) Close() {}
@@ -39,3 +39,11 @@ type Provider struct {
 	storeType                 store.Backend
 	kvClient                  store.Store
 }
+
+// snapshot holds the revisions of the packages of the universes
+type snapshot map[string]map[string]map[string]bool
+
+// instrumented is a store.Store, that observes the operations on the KV
+type instrumented struct {
+	store.Store
+}
@@ -0,0 +1,212 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kv
+
+import (
+	"path"
+	"strings"
+	"time"
+
+	"github.com/axelspringer/moppi/provider"
+	"github.com/docker/libkv/store"
+	"github.com/prometheus/common/log"
+)
+
+// Watch watches the universes for changes, also for those made directly in the KV.
+// The changes are sent until stop is closed, a lost watch is renewed and the
+// changes in between are sent then
+func (p *Provider) Watch(stop <-chan struct{}) <-chan provider.Change {
+	changes := make(chan provider.Change, watchBuffer)
+
+	go func() {
+		defer close(changes)
+
+		// the changes are relative to the first snapshot, so it has to succeed
+		retry := watchRetry
+		last, err := p.snapshot()
+		for err != nil {
+			log.Warnf("Could not list the universes: %v", err)
+
+			select {
+			case <-time.After(retry):
+			case <-stop:
+				return
+			}
+
+			if retry *= 2; retry > watchMaxRetry {
+				retry = watchMaxRetry
+			}
+			last, err = p.snapshot()
+		}
+		retry = watchRetry
+
+		closed := make(chan []*store.KVPair)
+		close(closed)
+
+		for {
+			watch, err := p.kvClient.WatchTree(universesPath(p.Prefix), stop)
+			if err != nil {
+				log.Warnf("Could not watch the universes: %v", err)
+				watch = closed
+			}
+
+			// the first notification catches up with the changes in between,
+			// a burst of notifications is listed once
+			for open := true; open; {
+				if _, open = <-watch; !open {
+					break
+				}
+				retry = watchRetry
+				open = settle(watch, stop)
+
+				next, err := p.snapshot()
+				if err != nil {
+					log.Warnf("Could not list the universes: %v", err)
+					continue
+				}
+
+				for _, change := range last.diff(next) {
+					select {
+					case changes <- change:
+					case <-stop:
+						return
+					}
+				}
+				last = next
+			}
+
+			// the watch is lost, renew it
+			select {
+			case <-time.After(retry):
+			case <-stop:
+				return
+			}
+
+			if retry *= 2; retry > watchMaxRetry {
+				retry = watchMaxRetry
+			}
+		}
+	}()
+
+	return changes
+}
+
+// settle waits until the notifications of the watch pause, but at most
+// watchMaxQuiet. It returns false, when the watch has been closed
+func settle(watch <-chan []*store.KVPair, stop <-chan struct{}) bool {
+	deadline := time.After(watchMaxQuiet)
+
+	for {
+		select {
+		case _, ok := <-watch:
+			if !ok {
+				return false
+			}
+		case <-time.After(watchQuiet):
+			return true
+		case <-deadline:
+			return true
+		case <-stop:
+			return false
+		}
+	}
+}
+
+// snapshot lists the revisions of the packages of the universes
+func (p *Provider) snapshot() (snapshot, error) {
+	s := make(snapshot)
+
+	universes, err := p.list(trailingSlash(universesPath(p.Prefix)))
+	if err != nil {
+		return s, err
+	}
+
+	for _, universe := range universes {
+		s[universe] = make(map[string]map[string]bool)
+
+		pkgs, err := p.list(trailingSlash(universePath(p.Prefix, universe) + provider.MoppiPackages))
+		if err != nil {
+			return s, err
+		}
+
+		for _, pkg := range pkgs {
+			s[universe][pkg] = make(map[string]bool)
+
+			revs, err := p.list(trailingSlash(universePkgBasePath(p.Prefix, universe, pkg)))
+			if err != nil {
+				return s, err
+			}
+
+			for _, rev := range revs {
+				s[universe][pkg][rev] = true
+			}
+		}
+	}
+
+	return s, nil
+}
+
+// list returns the names of the children of a directory
+func (p *Provider) list(dir string) ([]string, error) {
+	kvPairs, err := p.kvClient.List(dir)
+	if err == store.ErrKeyNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(kvPairs))
+	for _, kvPair := range kvPairs {
+		names = append(names, path.Base(strings.TrimSuffix(kvPair.Key, "/")))
+	}
+
+	return names, nil
+}
+
+// diff returns the changes from one snapshot to the next
+func (s snapshot) diff(next snapshot) []provider.Change {
+	var changes []provider.Change
+
+	for universe, pkgs := range next {
+		if _, ok := s[universe]; !ok {
+			changes = append(changes, provider.Change{Kind: provider.ChangeUniverseCreated, Universe: universe})
+		}
+
+		for pkg, revs := range pkgs {
+			for rev := range revs {
+				if !s[universe][pkg][rev] {
+					changes = append(changes, provider.Change{Kind: provider.ChangeRevisionAdded, Universe: universe, Package: pkg, Revision: rev})
+				}
+			}
+		}
+	}
+
+	for universe, pkgs := range s {
+		for pkg, revs := range pkgs {
+			for rev := range revs {
+				if !next[universe][pkg][rev] {
+					changes = append(changes, provider.Change{Kind: provider.ChangeRevisionDeleted, Universe: universe, Package: pkg, Revision: rev})
+				}
+			}
+		}
+
+		if _, ok := next[universe]; !ok {
+			changes = append(changes, provider.Change{Kind: provider.ChangeUniverseDeleted, Universe: universe})
+		}
+	}
+
+	return changes
+}
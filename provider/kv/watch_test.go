@@ -0,0 +1,154 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kv_test
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/axelspringer/moppi/provider"
+	"github.com/axelspringer/moppi/provider/kv"
+)
+
+var _ = Describe("Watch", func() {
+	var (
+		kvStore *memory
+		p       *kv.Provider
+		req     *provider.Request
+		pkg     *provider.Package
+		stop    chan struct{}
+	)
+
+	// watching returns a channel, that is closed when the
+	// universes are watched, after the first snapshot
+	watching := func(hook func(op string, key string) error) chan struct{} {
+		watched := make(chan struct{})
+		var once sync.Once
+		kvStore.hook = func(op string, key string) error {
+			if op == "watch" {
+				once.Do(func() { close(watched) })
+			}
+			if hook != nil {
+				return hook(op, key)
+			}
+			return nil
+		}
+		return watched
+	}
+
+	BeforeEach(func() {
+		kvStore = newMemory()
+		p = &kv.Provider{Prefix: "moppi"}
+		p.SetKVClient(kvStore)
+		stop = make(chan struct{})
+
+		req = &provider.Request{Universe: "prod", Name: "nginx"}
+		pkg = &provider.Package{Install: provider.Install{Marathon: true}}
+
+		Expect(p.CreateUniverse(&provider.Universe{Name: "prod", Description: "production", Version: "1"})).To(Succeed())
+		_, err := p.CreatePackageRevision(req, pkg, "")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		close(stop)
+	})
+
+	It("sends the added and deleted revisions and universes", func() {
+		watched := watching(nil)
+		changes := p.Watch(stop)
+		Eventually(watched).Should(BeClosed())
+
+		_, err := p.CreatePackageRevision(req, pkg, "")
+		Expect(err).NotTo(HaveOccurred())
+
+		var change provider.Change
+		Eventually(changes, 5*time.Second).Should(Receive(&change))
+		Expect(change).To(Equal(provider.Change{Kind: provider.ChangeRevisionAdded, Universe: "prod", Package: "nginx", Revision: "2"}))
+
+		Expect(p.DeleteUniverse(req)).To(Succeed())
+
+		received := make([]provider.Change, 0)
+		Eventually(func() []provider.Change {
+			select {
+			case change := <-changes:
+				received = append(received, change)
+			default:
+			}
+			return received
+		}, 5*time.Second).Should(ConsistOf(
+			provider.Change{Kind: provider.ChangeRevisionDeleted, Universe: "prod", Package: "nginx", Revision: "1"},
+			provider.Change{Kind: provider.ChangeRevisionDeleted, Universe: "prod", Package: "nginx", Revision: "2"},
+			provider.Change{Kind: provider.ChangeUniverseDeleted, Universe: "prod"},
+		))
+	})
+
+	It("retries the first snapshot, instead of sending everything as added", func() {
+		failed := false
+		watched := watching(func(op string, key string) error {
+			if op == "list" && key == "moppi/universes" && !failed {
+				failed = true
+				return errors.New("etcd is gone")
+			}
+			return nil
+		})
+
+		changes := p.Watch(stop)
+		Eventually(watched, 5*time.Second).Should(BeClosed())
+
+		_, err := p.CreatePackageRevision(req, pkg, "")
+		Expect(err).NotTo(HaveOccurred())
+
+		var change provider.Change
+		Eventually(changes, 5*time.Second).Should(Receive(&change))
+		Expect(change).To(Equal(provider.Change{Kind: provider.ChangeRevisionAdded, Universe: "prod", Package: "nginx", Revision: "2"}))
+		Consistently(changes).ShouldNot(Receive())
+	})
+
+	It("lists the tree once for a burst of notifications", func() {
+		var snapshots int32
+		watched := watching(func(op string, key string) error {
+			if op == "list" && key == "moppi/universes/prod/packages" {
+				atomic.AddInt32(&snapshots, 1)
+			}
+			return nil
+		})
+
+		changes := p.Watch(stop)
+		Eventually(watched).Should(BeClosed())
+		atomic.StoreInt32(&snapshots, 0)
+
+		for i := 0; i < 10; i++ {
+			_, err := p.CreatePackageRevision(req, pkg, "")
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		revisions := make(map[string]bool)
+		Eventually(func() int {
+			select {
+			case change := <-changes:
+				revisions[change.Revision] = true
+			default:
+			}
+			return len(revisions)
+		}, 5*time.Second).Should(Equal(10))
+		Expect(atomic.LoadInt32(&snapshots)).To(BeNumerically("<=", 2))
+	})
+})
@@ -15,7 +15,10 @@
 package provider
 
 import (
+	"time"
+
 	"github.com/axelspringer/go-chronos"
+	"github.com/axelspringer/moppi/metronome"
 	"github.com/docker/libkv/store"
 	"github.com/gambol99/go-marathon"
 )
@@ -24,10 +27,12 @@ import (
 type Provider interface {
 	Version() (*store.KVPair, error)
 	Setup() (bool, error)
+	IsSetup() (bool, error)
 	CreateUniverse(u *Universe) error
 	GetUniverse(req *Request) (*Universe, error)
 	GetUniverses() (*Universes, error)
 	GetRevisions(req *Request) (*PackageRevisions, error)
+	GetRevision(req *Request) (*Revision, error)
 	GetPackage(req *Request) (*Package, error)
 	GetPackages(req *Request) (*Packages, error)
 	// Packages() (map[string]map[int]*install.Package, error)
@@ -74,22 +79,86 @@ type Request struct {
 // Package describes a package in the universe
 type Package struct {
 	Chronos   []chronos.Job          `kvstructure:"chronos,json" json:"chronos" validate:"required"`
+	Metronome []metronome.Job        `kvstructure:"metronome,json" json:"metronome,omitempty"`
 	Marathon  []marathon.Application `kvstructure:"marathon,json" json:"marathon" validate:"required"`
+	Pods      []marathon.Pod         `kvstructure:"pods,json" json:"pods,omitempty"`
+	Groups    []marathon.Group       `kvstructure:"groups,json" json:"groups,omitempty"`
 	Install   Install                `kvstructure:"install,json" json:"install" validate:"required"`
 	Uninstall Uninstall              `kvstructure:"uninstall,json" json:"uninstall" validate:"required"`
 }
 
-// Install describes an installment (contained in install.json)
+// Revision describes the meta infos of a published package revision
+type Revision struct {
+	Digest    string `kvstructure:"digest" json:"digest"`
+	Created   string `kvstructure:"created" json:"created"`
+	Signature string `kvstructure:"signature" json:"signature,omitempty"`
+	Yanked    bool   `kvstructure:"yanked" json:"yanked"`
+	YankedAt  string `kvstructure:"yanked_at" json:"yanked_at,omitempty"`
+}
+
+// Install describes an installment (contained in install.json), the timeout
+// to wait on each Marathon app is in seconds
 type Install struct {
-	Marathon bool `json:"marathon"`
-	Chronos  bool `json:"chronos"`
-	Update   bool `json:"update"`
+	Marathon  bool     `json:"marathon"`
+	Chronos   bool     `json:"chronos"`
+	Update    bool     `json:"update"`
+	Timeout   int      `json:"timeout,omitempty" validate:"min=0"`
+	Wait      string   `json:"wait,omitempty" validate:"omitempty,eq=deployment|eq=healthy"`
+	Ordered   bool     `json:"ordered,omitempty"`
+	Metronome bool     `json:"metronome,omitempty"`
+	Run       []string `json:"run,omitempty"`
+
+	Frameworks []Framework `json:"frameworks,omitempty" validate:"dive"`
+}
+
+// Framework describes a Mesos framework, that is launched by a Marathon app of a package
+type Framework struct {
+	App  string `json:"app" validate:"required"`
+	Name string `json:"name" validate:"required"`
 }
 
 // Uninstall describes an uninstallment
 type Uninstall struct {
-	Marathon bool `json:"marathon"`
-	Chronos  bool `json:"chronos"`
+	Marathon  bool `json:"marathon"`
+	Chronos   bool `json:"chronos"`
+	Metronome bool `json:"metronome,omitempty"`
+}
+
+// AuditEntry describes a recorded mutating call or job outcome
+type AuditEntry struct {
+	ID       string    `json:"id"`
+	Time     time.Time `json:"time"`
+	Identity string    `json:"identity"`
+	SourceIP string    `json:"source_ip"`
+	Action   string    `json:"action"`
+	Universe string    `json:"universe,omitempty"`
+	Package  string    `json:"package,omitempty"`
+	Revision string    `json:"revision,omitempty"`
+	Job      string    `json:"job,omitempty"`
+	Summary  string    `json:"summary"`
+	Result   string    `json:"result"`
+	Status   int       `json:"status,omitempty"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// Change describes a change of a universe in the KV,
+// also one that was made directly in the KV
+type Change struct {
+	Kind     string
+	Universe string
+	Package  string
+	Revision string
+}
+
+// Webhook describes a subscription of an URL to events, the payloads
+// are signed with the secret (HMAC-SHA256)
+type Webhook struct {
+	ID       string    `json:"id"`
+	URL      string    `json:"url" validate:"required,url"`
+	Events   []string  `json:"events"`
+	Universe string    `json:"universe,omitempty"`
+	Secret   string    `json:"secret,omitempty"`
+	Created  time.Time `json:"created"`
 }
 
 // Universes describes known universes
@@ -0,0 +1,142 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue
+
+import (
+	"context"
+	"strings"
+)
+
+// Cancel cancels a job, a queued job is removed from the queue, and a running
+// job is stopped between its steps, what it created is deleted on a rollback
+func (q *Queue) Cancel(id string, rollback bool) (Job, error) {
+	job, ok := q.Jobs.Get(id)
+	if !ok {
+		return Job{}, ErrUnknownJob(id)
+	}
+
+	q.mu.Lock()
+	for i, work := range q.pending {
+		if queued := jobOf(work); queued != nil && queued.ID == id {
+			q.pending = append(q.pending[:i], q.pending[i+1:]...)
+			q.mu.Unlock()
+
+			q.Jobs.Finish(queued, ErrCanceled(id))
+			job, _ = q.Jobs.Get(id)
+
+			return job, nil
+		}
+	}
+
+	running, ok := q.running[id]
+	if ok {
+		running.rollback = rollback
+		running.cancel()
+	}
+	q.mu.Unlock()
+
+	if !ok {
+		return job, ErrFinished(id)
+	}
+	logger(&job).WithField("rollback", rollback).Warn("Canceling the job")
+
+	return job, nil
+}
+
+// context returns the context of a job, that is done when the job is canceled
+func (q *Queue) context(job *Job) context.Context {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	running, ok := q.running[job.ID]
+	if !ok {
+		return context.Background()
+	}
+
+	return running.ctx
+}
+
+// rollback checks if what a canceled job created is deleted
+func (q *Queue) rollback(job *Job) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	running, ok := q.running[job.ID]
+
+	return ok && running.rollback
+}
+
+// abort cancels the running Marathon deployments of a canceled installment, and deletes
+// the created Marathon apps, pods, groups, Chronos and Metronome jobs on a rollback
+func (i *Install) abort(rollback bool) {
+	log := logger(i.Job)
+
+	for _, r := range i.deployed {
+		if err := i.Installer.CancelDeployments(r.id()); err != nil {
+			log.WithError(err).WithField("app", r.id()).Error("Could not cancel the Marathon deployment")
+		}
+	}
+
+	if !rollback {
+		return
+	}
+
+	for n := len(i.created) - 1; n >= 0; n-- {
+		r := i.created[n]
+		log.WithField("app", r.id()).Info("Deleting from Marathon")
+		if _, err := r.remove(i.Installer.Marathon); err != nil {
+			log.WithError(err).WithField("app", r.id()).Error("Could not delete from Marathon")
+		}
+	}
+
+	for _, framework := range i.Package.Install.Frameworks {
+		if !i.launched(framework.App) {
+			continue
+		}
+
+		log.WithField("framework", framework.Name).Info("Shutting down the Mesos framework")
+		if err := i.Installer.TeardownFramework(framework.Name); err != nil {
+			log.WithError(err).WithField("framework", framework.Name).Error("Could not shut down the Mesos framework")
+		}
+	}
+
+	for n := len(i.jobs) - 1; n >= 0; n-- {
+		job := i.jobs[n]
+		log.WithField("chronos_job", job).Info("Deleting the Chronos job")
+		if err := i.Installer.DeleteChronosJob(job); err != nil {
+			log.WithError(err).WithField("chronos_job", job).Error("Could not delete the Chronos job")
+		}
+	}
+
+	for _, job := range i.metronome {
+		log.WithField("metronome_job", job).Info("Deleting the Metronome job")
+		if err := deleteMetronome(i.Installer.Metronome, job); err != nil {
+			log.WithError(err).WithField("metronome_job", job).Error("Could not delete the Metronome job")
+		}
+	}
+}
+
+// launched checks that an app was created by an installment, itself or in a group
+func (i *Install) launched(app string) bool {
+	app = absolute(app, "")
+	for _, r := range i.created {
+		id := absolute(r.id(), "")
+		if app == id || strings.HasPrefix(app, id+"/") {
+			return true
+		}
+	}
+
+	return false
+}
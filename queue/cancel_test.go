@@ -0,0 +1,130 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue_test
+
+import (
+	"time"
+
+	marathon "github.com/gambol99/go-marathon"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/axelspringer/moppi/cfg"
+	"github.com/axelspringer/moppi/provider"
+	"github.com/axelspringer/moppi/queue"
+)
+
+var _ = Describe("Cancel", func() {
+	var (
+		c    *cluster
+		jobs *queue.Jobs
+		q    *queue.Queue
+		pkg  *provider.Package
+	)
+
+	BeforeEach(func() {
+		instances := 2
+		c = newCluster()
+		q, jobs = newQueue(c, cfg.Retry{Attempts: 1})
+
+		pkg = &provider.Package{
+			Marathon: []marathon.Application{{ID: "/web", Instances: &instances}, {ID: "/api", Instances: &instances}},
+			Install:  provider.Install{Marathon: true, Update: true, Timeout: 5},
+		}
+	})
+
+	AfterEach(func() {
+		c.Close()
+	})
+
+	// waiting installs the package, and waits until it waits on its stalled deployments
+	waiting := func() queue.Job {
+		c.stalled = true
+		c.apps["/web"] = &marathon.Application{ID: "/web"}
+
+		job := installJob(c, pkg)
+		Eventually(func() []string { return c.Calls("GET /v2/apps/web") }, 5*time.Second).Should(HaveLen(2)) // exists, wait
+
+		return job
+	}
+
+	It("removes a queued job from the queue", func() {
+		release := c.Hold("PUT /v2/apps/web")
+		defer release()
+		c.apps["/web"] = &marathon.Application{ID: "/web"}
+		running := installJob(c, pkg)
+		Eventually(func() []string { return c.Calls("PUT /v2/apps/web") }).Should(HaveLen(1))
+
+		queued := installJob(c, pkg)
+		job, err := q.Cancel(queued.ID, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(job.State).To(Equal(queue.StateCanceled))
+		Expect(job.Started).To(BeNil())
+
+		release()
+		Expect(finish(jobs, running.ID).State).To(Equal(queue.StateSucceeded))
+		Expect(c.Calls("PUT /v2/apps/web")).To(HaveLen(1)) // the canceled job never ran
+	})
+
+	It("stops a running job, and cancels its Marathon deployments", func() {
+		job := waiting()
+
+		_, err := q.Cancel(job.ID, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(finish(jobs, job.ID).State).To(Equal(queue.StateCanceled))
+
+		Expect(c.Calls("DELETE /v2/deployments/deployment-web")).To(HaveLen(1))
+		Expect(c.Calls("DELETE /v2/deployments/deployment-api")).To(HaveLen(1))
+		Expect(c.apps).To(HaveKey("/web"))
+		Expect(c.apps).To(HaveKey("/api")) // no rollback
+	})
+
+	It("stops waiting on Marathon, when a running job is canceled", func() {
+		job := waiting()
+
+		_, err := q.Cancel(job.ID, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(finish(jobs, job.ID).State).To(Equal(queue.StateCanceled))
+
+		polls := len(c.Calls("GET /v2/apps/web"))
+		Consistently(func() []string { return c.Calls("GET /v2/apps/web") }, 2*time.Second).Should(HaveLen(polls))
+	})
+
+	It("deletes only what a running job created on a rollback", func() {
+		job := waiting()
+
+		_, err := q.Cancel(job.ID, true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(finish(jobs, job.ID).State).To(Equal(queue.StateCanceled))
+
+		Expect(c.Calls("DELETE /v2/apps/api")).To(HaveLen(1))
+		Expect(c.Calls("DELETE /v2/apps/web")).To(BeEmpty()) // it existed before
+		Expect(c.apps).To(HaveKey("/web"))
+		Expect(c.apps).NotTo(HaveKey("/api"))
+	})
+
+	It("does not cancel a finished job", func() {
+		job := finish(jobs, installJob(c, pkg).ID)
+		Expect(job.State).To(Equal(queue.StateSucceeded))
+
+		_, err := q.Cancel(job.ID, false)
+		Expect(err).To(Equal(queue.ErrFinished(job.ID)))
+	})
+
+	It("does not cancel an unknown job", func() {
+		_, err := q.Cancel("42", false)
+		Expect(err).To(Equal(queue.ErrUnknownJob("42")))
+	})
+})
@@ -0,0 +1,124 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue_test
+
+import (
+	chronos "github.com/axelspringer/go-chronos"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/axelspringer/moppi/cfg"
+	"github.com/axelspringer/moppi/installer"
+	"github.com/axelspringer/moppi/provider"
+	"github.com/axelspringer/moppi/queue"
+)
+
+var _ = Describe("Chronos jobs", func() {
+	var (
+		c    *cluster
+		jobs *queue.Jobs
+		pkg  *provider.Package
+	)
+
+	BeforeEach(func() {
+		c = newCluster()
+		_, jobs = newQueue(c, cfg.Retry{Attempts: 1})
+
+		pkg = &provider.Package{
+			Chronos: []chronos.Job{
+				{Name: "report", Parents: []string{"transform"}},
+				{Name: "transform", Parents: []string{"extract"}},
+				{Name: "extract", Schedule: "R/2017-10-01T02:00/P1D"},
+			},
+			Install:   provider.Install{Chronos: true},
+			Uninstall: provider.Uninstall{Chronos: true},
+		}
+	})
+
+	AfterEach(func() {
+		c.Close()
+	})
+
+	It("installs the parents before their dependent jobs", func() {
+		Expect(finish(jobs, installJob(c, pkg).ID).State).To(Equal(queue.StateSucceeded))
+		Expect(c.puts).To(Equal([]string{"extract", "transform", "report"}))
+		Expect(c.Calls("POST /scheduler/iso8601")).To(HaveLen(1))
+		Expect(c.Calls("POST /scheduler/dependency")).To(HaveLen(2))
+	})
+
+	It("updates the jobs, that exist", func() {
+		c.jobs["extract"] = chronos.Job{Name: "extract", Schedule: "R//P1W"}
+
+		Expect(finish(jobs, installJob(c, pkg).ID).State).To(Equal(queue.StateSucceeded))
+		Expect(c.jobs["extract"].Schedule).To(Equal("R/2017-10-01T02:00/P1D"))
+	})
+
+	It("runs the bootstrap jobs once, after the jobs are installed", func() {
+		pkg.Install.Run = []string{"extract"}
+
+		Expect(finish(jobs, installJob(c, pkg).ID).State).To(Equal(queue.StateSucceeded))
+		calls := c.Trace("")
+		Expect(c.Trace("PUT /scheduler/job/")).To(Equal([]string{"PUT /scheduler/job/extract"}))
+		Expect(calls[len(calls)-1]).To(Equal("PUT /scheduler/job/extract"))
+	})
+
+	It("rejects a bootstrap job, that is not part of the package", func() {
+		pkg.Install.Run = []string{"cleanup"}
+
+		job := finish(jobs, installJob(c, pkg).ID)
+		Expect(job.State).To(Equal(queue.StateFailed))
+		Expect(job.Error).To(Equal(installer.ErrNoChronosJob("cleanup").Error()))
+		Expect(c.puts).To(BeEmpty())
+	})
+
+	It("installs nothing, when a schedule is invalid", func() {
+		pkg.Chronos[2].Schedule = "daily"
+
+		Expect(finish(jobs, installJob(c, pkg).ID).State).To(Equal(queue.StateFailed))
+		Expect(c.Trace("")).To(BeEmpty())
+	})
+
+	It("deletes the dependent jobs before their parents", func() {
+		Expect(finish(jobs, installJob(c, pkg).ID).State).To(Equal(queue.StateSucceeded))
+
+		Expect(finish(jobs, uninstallJob(c, pkg).ID).State).To(Equal(queue.StateSucceeded))
+		Expect(c.Trace("DELETE /scheduler/job/")).To(Equal([]string{
+			"DELETE /scheduler/job/report",
+			"DELETE /scheduler/job/transform",
+			"DELETE /scheduler/job/extract",
+		}))
+		Expect(c.jobs).To(BeEmpty())
+	})
+
+	It("deletes the jobs in the order of the package, when they cannot be sorted", func() {
+		for _, job := range pkg.Chronos {
+			c.jobs[job.Name] = job
+		}
+		pkg.Chronos[2].Schedule = "daily" // installed before the schedules were validated
+
+		Expect(finish(jobs, uninstallJob(c, pkg).ID).State).To(Equal(queue.StateSucceeded))
+		Expect(c.Trace("DELETE /scheduler/job/")).To(Equal([]string{
+			"DELETE /scheduler/job/extract",
+			"DELETE /scheduler/job/transform",
+			"DELETE /scheduler/job/report",
+		}))
+		Expect(c.jobs).To(BeEmpty())
+	})
+
+	It("succeeds, when the jobs are deleted already", func() {
+		Expect(finish(jobs, uninstallJob(c, pkg).ID).State).To(Equal(queue.StateSucceeded))
+		Expect(c.Trace("DELETE /scheduler/job/")).To(HaveLen(3))
+	})
+})
@@ -0,0 +1,539 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	chronos "github.com/axelspringer/go-chronos"
+	"github.com/axelspringer/moppi/cfg"
+	"github.com/axelspringer/moppi/installer"
+	"github.com/axelspringer/moppi/mesos"
+	"github.com/axelspringer/moppi/metronome"
+	"github.com/axelspringer/moppi/provider"
+	"github.com/axelspringer/moppi/queue"
+	marathon "github.com/gambol99/go-marathon"
+
+	. "github.com/onsi/gomega"
+)
+
+// cluster fakes Marathon, Chronos, Mesos and Metronome, it records
+// every call as "METHOD /path", and answers a call with a failure once
+type cluster struct {
+	Marathon  *httptest.Server
+	Chronos   *httptest.Server
+	Mesos     *httptest.Server
+	Metronome *httptest.Server
+
+	queue       *queue.Queue // stopped, when the cluster is closed
+	apps        map[string]*marathon.Application
+	pods        map[string]*marathon.Pod
+	groups      map[string]*marathon.Group
+	deployments []*marathon.Deployment
+	jobs        map[string]chronos.Job
+	puts        []string // the names of the Chronos jobs in the order they were put
+	frameworks  []*mesos.Framework
+	timers      map[string]*metronome.Job
+	unhealthy   bool // the tasks fail their health checks
+	stalled     bool // the deployments of the apps do not finish
+	holds       map[string]chan struct{}
+	calls       []string
+	failures    map[string][]failure
+	mu          sync.Mutex
+}
+
+// failure answers a call with a status, the call is handled before, when it is lost
+type failure struct {
+	status int
+	lost   bool
+}
+
+// newCluster starts the fake servers
+func newCluster() *cluster {
+	c := &cluster{
+		apps:     make(map[string]*marathon.Application),
+		pods:     make(map[string]*marathon.Pod),
+		groups:   make(map[string]*marathon.Group),
+		jobs:     make(map[string]chronos.Job),
+		timers:   make(map[string]*metronome.Job),
+		failures: make(map[string][]failure),
+		holds:    make(map[string]chan struct{}),
+	}
+	c.Marathon = httptest.NewServer(c.handler(c.marathon))
+	c.Chronos = httptest.NewServer(c.handler(c.chronos))
+	c.Mesos = httptest.NewServer(c.handler(c.mesos))
+	c.Metronome = httptest.NewServer(c.handler(c.metronome))
+
+	return c
+}
+
+// newQueue returns a queue with one worker, that retries as configured, and its jobs.
+// The queue is stopped, when the cluster is closed
+func newQueue(c *cluster, retry cfg.Retry) (*queue.Queue, *queue.Jobs) {
+	jobs := queue.NewJobs()
+	c.queue = queue.New(1, 10, jobs)
+	c.queue.Retry(retry)
+
+	return c.queue, jobs
+}
+
+// installJob enqueues the installment of a package on the cluster
+func installJob(c *cluster, pkg *provider.Package) queue.Job {
+	return enqueue(c.queue, &queue.Install{Universe: "prod", Package: pkg, Installer: c.Installer(), Job: newJob(queue.KindInstall, "nginx")})
+}
+
+// uninstallJob enqueues the uninstallment of a package on the cluster
+func uninstallJob(c *cluster, pkg *provider.Package) queue.Job {
+	return enqueue(c.queue, &queue.Uninstall{Package: pkg, Installer: c.Installer(), Job: newJob(queue.KindUninstall, "nginx")})
+}
+
+// Close stops the queue and the fake servers
+func (c *cluster) Close() {
+	if c.queue != nil {
+		c.queue.Stop(time.Second)
+	}
+
+	c.Marathon.Close()
+	c.Chronos.Close()
+	c.Mesos.Close()
+	c.Metronome.Close()
+}
+
+// Installer returns an installer, that talks to the fake servers
+func (c *cluster) Installer() *installer.Installer {
+	installer, err := installer.New(&cfg.Config{
+		Marathon:  c.Marathon.URL,
+		Chronos:   c.Chronos.URL,
+		Mesos:     c.Mesos.URL,
+		Metronome: c.Metronome.URL,
+	})
+	Expect(err).NotTo(HaveOccurred())
+
+	return installer
+}
+
+// Fail answers the next call with a status, a lost call is handled anyway
+func (c *cluster) Fail(call string, status int, lost bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.failures[call] = append(c.failures[call], failure{status, lost})
+}
+
+// Hold holds the next call, until it is released
+func (c *cluster) Hold(call string) (release func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	hold := make(chan struct{})
+	c.holds[call] = hold
+
+	var once sync.Once
+	return func() { once.Do(func() { close(hold) }) }
+}
+
+// Calls returns the recorded calls of a method and a path
+func (c *cluster) Calls(call string) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	calls := make([]string, 0)
+	for _, recorded := range c.calls {
+		if recorded == call {
+			calls = append(calls, recorded)
+		}
+	}
+
+	return calls
+}
+
+// Trace returns the recorded calls, that start with any of the prefixes, in their order
+func (c *cluster) Trace(prefixes ...string) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	calls := make([]string, 0)
+	for _, call := range c.calls {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(call, prefix) {
+				calls = append(calls, call)
+				break
+			}
+		}
+	}
+
+	return calls
+}
+
+// handler records a call, and answers it with a pending failure
+func (c *cluster) handler(serve func(w http.ResponseWriter, r *http.Request)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := r.Method + " " + r.URL.Path
+
+		c.mu.Lock()
+		c.calls = append(c.calls, call)
+		hold := c.holds[call]
+		delete(c.holds, call)
+		var fail *failure
+		if failures := c.failures[call]; len(failures) > 0 {
+			fail = &failures[0]
+			c.failures[call] = failures[1:]
+		}
+		c.mu.Unlock()
+
+		if hold != nil {
+			<-hold
+		}
+
+		if fail == nil {
+			serve(w, r)
+			return
+		}
+
+		if fail.lost {
+			serve(httptest.NewRecorder(), r)
+		}
+		reply(w, fail.status, map[string]string{"message": http.StatusText(fail.status)})
+	})
+}
+
+// marathon serves the apps, pods, groups and deployments of Marathon
+func (c *cluster) marathon(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	path := r.URL.Path
+	switch {
+	case path == "/v2/apps" && r.Method == http.MethodPost:
+		app := new(marathon.Application)
+		if !decode(w, r, app) {
+			return
+		}
+		if _, ok := c.apps[app.ID]; ok {
+			reply(w, http.StatusConflict, map[string]string{"message": "An app with id [" + app.ID + "] already exists."})
+			return
+		}
+		c.apps[app.ID] = app
+		c.deploy(app.ID)
+		reply(w, http.StatusCreated, app)
+	case strings.HasPrefix(path, "/v2/apps/"):
+		id := strings.TrimPrefix(path, "/v2/apps")
+		app, ok := c.apps[id]
+		switch {
+		case r.Method == http.MethodPut:
+			app = new(marathon.Application)
+			if !decode(w, r, app) {
+				return
+			}
+			c.apps[id] = app
+			c.deploy(id)
+			reply(w, http.StatusOK, deployment(id))
+		case !ok:
+			reply(w, http.StatusNotFound, map[string]string{"message": "App '" + id + "' does not exist"})
+		case r.Method == http.MethodDelete:
+			delete(c.apps, id)
+			reply(w, http.StatusOK, deployment(id))
+		default:
+			reply(w, http.StatusOK, map[string]interface{}{"app": c.running(app)})
+		}
+	case path == "/v2/pods" && r.Method == http.MethodPost:
+		pod := new(marathon.Pod)
+		if !decode(w, r, pod) {
+			return
+		}
+		c.pods[pod.ID] = pod
+		reply(w, http.StatusCreated, pod)
+	case strings.HasPrefix(path, "/v2/pods/"):
+		id := strings.TrimPrefix(path, "/v2/pods")
+		status := strings.HasSuffix(id, "::status")
+		id = strings.TrimSuffix(id, "::status")
+		pod, ok := c.pods[id]
+		switch {
+		case r.Method == http.MethodPut:
+			pod = new(marathon.Pod)
+			if !decode(w, r, pod) {
+				return
+			}
+			c.pods[id] = pod
+			reply(w, http.StatusOK, pod)
+		case !ok:
+			reply(w, http.StatusNotFound, map[string]string{"message": "Pod '" + id + "' does not exist"})
+		case r.Method == http.MethodDelete:
+			delete(c.pods, id)
+			reply(w, http.StatusAccepted, deployment(id))
+		case status:
+			reply(w, http.StatusOK, map[string]string{"id": id, "status": "STABLE"})
+		default:
+			reply(w, http.StatusOK, pod)
+		}
+	case path == "/v2/groups" && r.Method == http.MethodPost:
+		group := new(marathon.Group)
+		if !decode(w, r, group) {
+			return
+		}
+		c.groups[group.ID] = group
+		for _, app := range installer.GroupApps(group) {
+			c.apps[app.ID] = app
+		}
+		reply(w, http.StatusCreated, deployment(group.ID))
+	case strings.HasPrefix(path, "/v2/groups/"):
+		id := strings.TrimPrefix(path, "/v2/groups")
+		group, ok := c.groups[id]
+		switch {
+		case r.Method == http.MethodPut:
+			group = new(marathon.Group)
+			if !decode(w, r, group) {
+				return
+			}
+			c.groups[id] = group
+			for _, app := range installer.GroupApps(group) {
+				c.apps[app.ID] = app
+			}
+			reply(w, http.StatusOK, deployment(id))
+		case !ok:
+			reply(w, http.StatusNotFound, map[string]string{"message": "Group '" + id + "' does not exist"})
+		case r.Method == http.MethodDelete:
+			for _, app := range installer.GroupApps(group) {
+				delete(c.apps, app.ID)
+			}
+			delete(c.groups, id)
+			reply(w, http.StatusOK, deployment(id))
+		default:
+			reply(w, http.StatusOK, group)
+		}
+	case path == "/v2/deployments":
+		reply(w, http.StatusOK, c.deployments)
+	case strings.HasPrefix(path, "/v2/deployments/") && r.Method == http.MethodDelete:
+		id := strings.TrimPrefix(path, "/v2/deployments/")
+		for n, d := range c.deployments {
+			if d.ID == id {
+				c.deployments = append(c.deployments[:n], c.deployments[n+1:]...)
+				break
+			}
+		}
+		reply(w, http.StatusAccepted, nil)
+	default:
+		reply(w, http.StatusNotFound, nil)
+	}
+}
+
+// deploy starts a deployment of an app, that does not finish, when the cluster is stalled
+func (c *cluster) deploy(id string) {
+	if c.stalled {
+		c.deployments = append(c.deployments, &marathon.Deployment{ID: deployment(id).DeploymentID, AffectedApps: []string{id}})
+	}
+}
+
+// running returns an app with all its tasks running, and passing their health
+// checks, no task of an app is running, when the cluster is stalled
+func (c *cluster) running(app *marathon.Application) *marathon.Application {
+	running := *app
+	instances := 1
+	if app.Instances != nil {
+		instances = *app.Instances
+	}
+
+	if c.stalled {
+		running.Tasks = make([]*marathon.Task, 0)
+		return &running
+	}
+
+	running.Tasks = make([]*marathon.Task, 0, instances)
+	for n := 0; n < instances; n++ {
+		running.Tasks = append(running.Tasks, &marathon.Task{
+			AppID:              app.ID,
+			HealthCheckResults: []*marathon.HealthCheckResult{{Alive: !c.unhealthy}},
+		})
+	}
+	running.TasksRunning = instances
+
+	return &running
+}
+
+// chronos serves the scheduled and dependent jobs of Chronos
+func (c *cluster) chronos(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	path := r.URL.Path
+	switch {
+	case path == "/scheduler/jobs":
+		jobs := make([]chronos.Job, 0, len(c.jobs))
+		for _, job := range c.jobs {
+			jobs = append(jobs, job)
+		}
+		reply(w, http.StatusOK, jobs)
+	case path == "/scheduler/iso8601" || path == "/scheduler/dependency":
+		var job chronos.Job
+		if !decode(w, r, &job) {
+			return
+		}
+		c.jobs[job.Name] = job
+		c.puts = append(c.puts, job.Name)
+		w.WriteHeader(http.StatusNoContent)
+	case strings.HasPrefix(path, "/scheduler/job/"):
+		name := strings.TrimPrefix(path, "/scheduler/job/")
+		if _, ok := c.jobs[name]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodDelete {
+			delete(c.jobs, name)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// mesos serves the state of a Mesos master with one agent, and tears down frameworks
+func (c *cluster) mesos(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch r.URL.Path {
+	case "/master/state":
+		resources := mesos.Resources{CPUs: 64, Mem: 262144, Disk: 1048576}
+		reply(w, http.StatusOK, &mesos.State{
+			Agents: []*mesos.Agent{{
+				ID:                  "agent-1",
+				Hostname:            "agent-1",
+				Active:              true,
+				Resources:           resources,
+				UnreservedResources: resources,
+			}},
+			Frameworks: c.frameworks,
+		})
+	case "/master/teardown":
+		id := r.FormValue("frameworkId")
+		for n, framework := range c.frameworks {
+			if framework.ID == id {
+				c.frameworks = append(c.frameworks[:n], c.frameworks[n+1:]...)
+				break
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// metronome serves the jobs of Metronome and their schedules
+func (c *cluster) metronome(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/v1/jobs"), "/")
+	if len(parts) == 1 && r.Method == http.MethodPost {
+		job := new(metronome.Job)
+		if !decode(w, r, job) {
+			return
+		}
+		if _, ok := c.timers[job.ID]; ok {
+			reply(w, http.StatusConflict, map[string]string{"message": "Job with this id already exists"})
+			return
+		}
+		c.timers[job.ID] = job
+		reply(w, http.StatusCreated, job)
+		return
+	}
+
+	if len(parts) < 2 {
+		reply(w, http.StatusNotFound, nil)
+		return
+	}
+
+	job, ok := c.timers[parts[1]]
+	if !ok {
+		reply(w, http.StatusNotFound, map[string]string{"message": "Job '" + parts[1] + "' does not exist"})
+		return
+	}
+
+	if len(parts) == 2 {
+		switch r.Method {
+		case http.MethodPut:
+			update := new(metronome.Job)
+			if !decode(w, r, update) {
+				return
+			}
+			update.Schedules = job.Schedules
+			c.timers[job.ID] = update
+		case http.MethodDelete:
+			delete(c.timers, job.ID)
+		}
+		reply(w, http.StatusOK, job)
+		return
+	}
+
+	schedules := make(map[string]metronome.Schedule)
+	for _, schedule := range job.Schedules {
+		schedules[schedule.ID] = schedule
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		reply(w, http.StatusOK, job.Schedules)
+		return
+	case http.MethodPost, http.MethodPut:
+		var schedule metronome.Schedule
+		if !decode(w, r, &schedule) {
+			return
+		}
+		if _, ok := schedules[schedule.ID]; ok && r.Method == http.MethodPost {
+			reply(w, http.StatusConflict, map[string]string{"message": "Schedule already exists"})
+			return
+		}
+		schedules[schedule.ID] = schedule
+	case http.MethodDelete:
+		delete(schedules, parts[len(parts)-1])
+	}
+
+	job.Schedules = make([]metronome.Schedule, 0, len(schedules))
+	for _, schedule := range schedules {
+		job.Schedules = append(job.Schedules, schedule)
+	}
+	sort.Slice(job.Schedules, func(i, j int) bool { return job.Schedules[i].ID < job.Schedules[j].ID })
+	reply(w, http.StatusOK, nil)
+}
+
+// deployment returns the deployment of a change of a Marathon app, pod or group
+func deployment(id string) *marathon.DeploymentID {
+	return &marathon.DeploymentID{DeploymentID: "deployment" + strings.Replace(id, "/", "-", -1), Version: "1"}
+}
+
+// decode decodes the body of a request, a malformed body is answered with a bad request
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		reply(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+		return false
+	}
+
+	return true
+}
+
+// reply writes a status and a value as JSON
+func reply(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if v != nil {
+		json.NewEncoder(w).Encode(v)
+	}
+}
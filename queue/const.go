@@ -0,0 +1,69 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue
+
+import "time"
+
+const (
+	// KindInstall is the kind of an installment
+	KindInstall = "install"
+	// KindUninstall is the kind of an uninstallment
+	KindUninstall = "uninstall"
+)
+
+const (
+	// StateQueued is the state of a job waiting for a worker
+	StateQueued = "queued"
+	// StateRunning is the state of a job a worker is working on
+	StateRunning = "running"
+	// StateSucceeded is the state of a successfully finished job
+	StateSucceeded = "succeeded"
+	// StateFailed is the state of a failed job
+	StateFailed = "failed"
+	// StateInterrupted is the state of a job, that was queued
+	// or running when moppi was shut down
+	StateInterrupted = "interrupted"
+	// StateCanceled is the state of a job, that was canceled
+	StateCanceled = "canceled"
+)
+
+const (
+	// DefaultCapacity is the number of jobs, that can wait for a worker by default
+	DefaultCapacity = 100
+	// maxWorkers is the maximum number of idle workers
+	maxWorkers = 1024
+)
+
+const (
+	// DefaultAttempts is the number of attempts of a call by default
+	DefaultAttempts = 5
+	// DefaultBackoff is the backoff after the first failed attempt by default
+	DefaultBackoff = time.Second
+	// DefaultMaxBackoff is the maximum backoff between two attempts by default
+	DefaultMaxBackoff = 30 * time.Second
+)
+
+const (
+	// jobsRetention is the number of finished jobs that are kept
+	jobsRetention = 1000
+	// defaultWait is the time to wait on a Marathon app, when the package has no timeout
+	defaultWait = 5 * time.Minute
+	// deployPoll is the interval, in which a Marathon deployment is checked
+	deployPoll = 500 * time.Millisecond
+	// healthPoll is the interval, in which the health of a Marathon app is checked
+	healthPoll = 2 * time.Second
+	// stopPoll is the interval, in which a stopped queue checks for running jobs
+	stopPoll = 100 * time.Millisecond
+)
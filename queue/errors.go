@@ -0,0 +1,84 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue
+
+import "fmt"
+
+// ErrInterrupted is the error of a job, that was interrupted by a shutdown
+type ErrInterrupted string
+
+// Error returns a custom error
+func (err ErrInterrupted) Error() string {
+	return fmt.Sprintf("Job %v was interrupted by a shutdown of moppi", string(err))
+}
+
+// ErrUnhealthy is returned when the instances of a Marathon app are not healthy in time
+type ErrUnhealthy string
+
+// Error returns a custom error
+func (err ErrUnhealthy) Error() string {
+	return fmt.Sprintf("The Marathon app %v did not become healthy in time", string(err))
+}
+
+// ErrFramework is returned when a Mesos framework is not active in time
+type ErrFramework string
+
+// Error returns a custom error
+func (err ErrFramework) Error() string {
+	return fmt.Sprintf("The Mesos framework %v did not become active in time", string(err))
+}
+
+// ErrNoApp is returned when a Mesos framework is launched by an app, that is not part of the package
+type ErrNoApp struct {
+	App       string
+	Framework string
+}
+
+// Error returns a custom error
+func (err ErrNoApp) Error() string {
+	return fmt.Sprintf("The Mesos framework %v is launched by %v, which is not part of the package", err.Framework, err.App)
+}
+
+// ErrCanceled is the error of a job, that was canceled
+type ErrCanceled string
+
+// Error returns a custom error
+func (err ErrCanceled) Error() string {
+	return fmt.Sprintf("Job %v was canceled", string(err))
+}
+
+// ErrUnknownJob is returned when a job is not known
+type ErrUnknownJob string
+
+// Error returns a custom error
+func (err ErrUnknownJob) Error() string {
+	return fmt.Sprintf("Unknown job %v", string(err))
+}
+
+// ErrFinished is returned when a finished job is canceled
+type ErrFinished string
+
+// Error returns a custom error
+func (err ErrFinished) Error() string {
+	return fmt.Sprintf("Job %v is already finished", string(err))
+}
+
+// ErrFull is returned when the queue is full
+type ErrFull int
+
+// Error returns a custom error
+func (err ErrFull) Error() string {
+	return fmt.Sprintf("The queue is full with %d waiting jobs", int(err))
+}
@@ -14,38 +14,606 @@
 
 package queue
 
-import "time"
+import (
+	"context"
+	"time"
 
-func install(i *Install) error {
-	installer := i.Installer
+	chronos "github.com/axelspringer/go-chronos"
+	"github.com/axelspringer/moppi/cfg"
+	"github.com/axelspringer/moppi/events"
+	"github.com/axelspringer/moppi/installer"
+	"github.com/axelspringer/moppi/metronome"
+	"github.com/axelspringer/moppi/provider"
+	marathon "github.com/gambol99/go-marathon"
+	log "github.com/sirupsen/logrus"
+)
+
+func install(ctx context.Context, i *Install, call caller) error {
 	pkg := i.Package
+	log := logger(i.Job)
+
+	// verify the publisher signature before anything is deployed
+	if i.Verifier != nil {
+		if err := i.Verifier.Verify(i.Universe, pkg, i.Revision.Signature); err != nil {
+			return err
+		}
+	}
+
+	// validate the Chronos jobs before anything is deployed
+	var jobs []chronos.Job
+	if pkg.Install.Chronos {
+		var err error
+		if jobs, err = chronosJobs(pkg); err != nil {
+			return err
+		}
+	}
+
+	// the Mesos frameworks must be launched by apps of the package
+	if err := frameworks(pkg); err != nil {
+		return err
+	}
+
+	// check that the Marathon apps and pods can be scheduled before anything is deployed
+	if pkg.Install.Marathon {
+		err := call("mesos.capacity", func() error {
+			return i.Installer.CheckCapacity(marathonApps(pkg), marathonPods(pkg), pkg.Install.Update)
+		})
+		if err != nil {
+			log.WithError(err).Error("The package does not fit into the cluster")
+			return err
+		}
+	}
 
-	// deploy marathon
+	// deploy marathon, an ordered install waits on each app, pod or group
+	// before the next one is deployed, otherwise they are deployed together
 	if pkg.Install.Marathon {
-		for _, marathon := range pkg.Marathon {
-			if _, err := installer.Marathon.CreateApplication(&marathon); err != nil {
+		timeout := waitTimeout(pkg.Install)
+		waiting := make([]resource, 0)
+		for _, r := range resources(pkg) {
+			if err := i.deploy(r, call); err != nil {
 				return err
 			}
+			waiting = append(waiting, r)
 
-			if err := installer.Marathon.WaitOnApplication(marathon.ID, 10*time.Second); err != nil {
+			if !pkg.Install.Ordered {
+				continue
+			}
+
+			if err := i.wait(ctx, r, timeout, call); err != nil {
+				return err
+			}
+			waiting = waiting[:0]
+		}
+
+		for _, r := range waiting {
+			if err := i.wait(ctx, r, timeout, call); err != nil {
+				return err
+			}
+		}
+
+		for _, framework := range pkg.Install.Frameworks {
+			if err := i.framework(ctx, framework.Name, timeout, call); err != nil {
 				return err
 			}
 		}
 	}
 
-	// deploy chronos
+	// deploy chronos, the parents before their dependent jobs, and
+	// run the bootstrap jobs once
 	if pkg.Install.Chronos {
-		for _, chronos := range pkg.Chronos {
-			if ok, _, err := installer.Chronos.Job.New(&chronos); !ok {
+		var existing map[string]bool
+		err := call("chronos.list", func() (err error) {
+			existing, err = i.Installer.ChronosJobs()
+			return err
+		})
+		if err != nil {
+			log.WithError(err).Error("Could not list the Chronos jobs")
+			return err
+		}
+
+		for _, chronos := range jobs {
+			job := log.WithField("chronos_job", chronos.Name)
+
+			job.Info("Deploying the Chronos job")
+			err := call("chronos.deploy "+chronos.Name, func() error {
+				return i.Installer.PutChronosJob(&chronos)
+			})
+			if err != nil {
+				job.WithError(err).Error("Could not deploy the Chronos job")
+				return err
+			}
+
+			if !existing[chronos.Name] {
+				i.jobs = append(i.jobs, chronos.Name)
+			}
+		}
+
+		for _, name := range pkg.Install.Run {
+			job := log.WithField("chronos_job", name)
+
+			job.Info("Running the Chronos job")
+			err := call("chronos.run "+name, func() error {
+				return i.Installer.RunChronosJob(name)
+			})
+			if err != nil {
+				job.WithError(err).Error("Could not run the Chronos job")
+				return err
+			}
+		}
+	}
+
+	// deploy metronome
+	if pkg.Install.Metronome && len(pkg.Metronome) > 0 {
+		client := i.Installer.Metronome
+		if client == nil {
+			return installer.ErrNoMetronome(i.Job.Request.Name)
+		}
+
+		for n := range pkg.Metronome {
+			metronome := &pkg.Metronome[n]
+			job := log.WithField("metronome_job", metronome.ID)
+
+			job.Info("Deploying the Metronome job")
+			var created bool
+			err := call("metronome.deploy "+metronome.ID, func() (err error) {
+				if created {
+					// an earlier attempt may have created the job, before it failed
+					return redeployMetronome(client, metronome)
+				}
+				created, err = deployMetronome(client, metronome, pkg.Install.Update)
+				return err
+			})
+			if err != nil {
+				job.WithError(err).Error("Could not deploy the Metronome job")
+				return err
+			}
+
+			if created {
+				i.metronome = append(i.metronome, metronome.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+// deployMetronome creates a Metronome job with its schedules, or updates them,
+// it returns if the job is created, even when the call fails
+func deployMetronome(client *metronome.Metronome, job *metronome.Job, update bool) (bool, error) {
+	if update {
+		_, err := client.Job(job.ID)
+		if err == nil {
+			return false, client.UpdateJob(job)
+		}
+
+		if !metronome.NotFound(err) {
+			return false, err
+		}
+	}
+
+	return true, client.CreateJob(job)
+}
+
+// redeployMetronome retries the creation of a Metronome job, a job that exists
+// was created by the failed attempt, its missing schedules are created
+func redeployMetronome(client *metronome.Metronome, job *metronome.Job) error {
+	_, err := client.Job(job.ID)
+	if metronome.NotFound(err) {
+		return client.CreateJob(job)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return client.UpdateJob(job)
+}
+
+// deploy deploys a Marathon app, pod or group of an installment
+func (i *Install) deploy(r resource, call caller) error {
+	log := logger(i.Job).WithField("app", r.id())
+
+	i.progress(r.id(), events.AppStarted, nil)
+	log.Info("Deploying to Marathon")
+	var created bool
+	err := call("marathon.deploy "+r.id(), func() (err error) {
+		if created {
+			// an earlier attempt may have created it, before it failed
+			if exists, err := r.exists(i.Installer.Marathon); err != nil || exists {
+				return err
+			}
+		}
+		created, err = r.deploy(i.Installer.Marathon, i.Package.Install.Update)
+		return err
+	})
+	if err != nil {
+		log.WithError(err).Error("Could not deploy to Marathon")
+		i.progress(r.id(), events.AppFailed, err)
+		return err
+	}
+
+	i.deployed = append(i.deployed, r)
+	if created {
+		i.created = append(i.created, r)
+	}
+
+	return nil
+}
+
+// wait waits on a Marathon app, pod or group of an installment, until
+// its deployment is finished, or until all its instances are healthy
+func (i *Install) wait(ctx context.Context, r resource, timeout time.Duration, call caller) error {
+	log := logger(i.Job).WithField("app", r.id())
+	client := i.Installer.Marathon
+	deadline := time.Now().Add(timeout)
+
+	i.progress(r.id(), events.AppWaiting, nil)
+	log.Info("Waiting on Marathon")
+	err := call("marathon.wait "+r.id(), func() error {
+		return deployed(ctx, client, r, deadline)
+	})
+	if err == nil && i.Package.Install.Wait == provider.WaitHealthy {
+		err = call("marathon.healthy "+r.id(), func() error {
+			return healthy(ctx, client, r, deadline)
+		})
+	}
+	if err != nil {
+		log.WithError(err).Error("Marathon deployment did not become healthy")
+		i.progress(r.id(), events.AppFailed, err)
+		return err
+	}
+	i.progress(r.id(), events.AppHealthy, nil)
+
+	return nil
+}
+
+// framework waits on a Mesos framework, that is launched by an app of an installment, until it is active
+func (i *Install) framework(ctx context.Context, name string, timeout time.Duration, call caller) error {
+	log := logger(i.Job).WithField("framework", name)
+	deadline := time.Now().Add(timeout)
+
+	log.Info("Waiting on the Mesos framework")
+	err := call("mesos.framework "+name, func() error {
+		return active(ctx, i.Installer, name, deadline)
+	})
+	if err != nil {
+		log.WithError(err).Error("Mesos framework did not become active")
+		return err
+	}
+
+	return nil
+}
+
+// deployed polls a Marathon app, pod or group, until it is deployed and all its instances are running
+func deployed(ctx context.Context, client marathon.Marathon, r resource, deadline time.Time) error {
+	for {
+		if r.running(client) {
+			return nil
+		}
+
+		if time.Now().Add(deployPoll).After(deadline) {
+			return marathon.ErrTimeoutError
+		}
+
+		select {
+		case <-time.After(deployPoll):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// undeployed polls a Marathon deployment, until it is finished
+func undeployed(ctx context.Context, client marathon.Marathon, id string, deadline time.Time) error {
+	for {
+		found, err := client.HasDeployment(id)
+		if err != nil {
+			return err
+		}
+
+		if !found {
+			return nil
+		}
+
+		if time.Now().Add(deployPoll).After(deadline) {
+			return marathon.ErrTimeoutError
+		}
+
+		select {
+		case <-time.After(deployPoll):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// healthy polls a Marathon app, pod or group, until all its instances pass their health checks
+func healthy(ctx context.Context, client marathon.Marathon, r resource, deadline time.Time) error {
+	for {
+		ok, err := r.healthy(client)
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			return nil
+		}
+
+		if time.Now().Add(healthPoll).After(deadline) {
+			return ErrUnhealthy(r.id())
+		}
+
+		select {
+		case <-time.After(healthPoll):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// active polls Mesos, until a framework is registered and active
+func active(ctx context.Context, client *installer.Installer, name string, deadline time.Time) error {
+	for {
+		ok, err := client.FrameworkActive(name)
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			return nil
+		}
+
+		if time.Now().Add(healthPoll).After(deadline) {
+			return ErrFramework(name)
+		}
+
+		select {
+		case <-time.After(healthPoll):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// waitTimeout returns the time to wait on each Marathon app of an installment
+func waitTimeout(install provider.Install) time.Duration {
+	if install.Timeout <= 0 {
+		return defaultWait
+	}
+
+	return time.Duration(install.Timeout) * time.Second
+}
+
+// progress publishes the progress of a Marathon app of an installment
+func (i *Install) progress(app string, state string, err error) {
+	if i.Events == nil {
+		return
+	}
+
+	event := events.Event{
+		Type:     events.TypeApp,
+		Universe: i.Universe,
+		App:      app,
+		State:    state,
+	}
+
+	if i.Job != nil {
+		event.Job = i.Job.ID
+		event.Package = i.Job.Request.Name
+		event.Revision = i.Job.Request.Revision
+	}
+
+	if err != nil {
+		event.Error = err.Error()
+	}
+
+	i.Events.Publish(event)
+}
+
+// uninstall deletes the Marathon apps, pods and groups of a package in the reverse
+// order of their installment and waits on their deployments, and deletes its Chronos and Metronome jobs
+func uninstall(ctx context.Context, u *Uninstall, call caller) error {
+	pkg := u.Package
+	client := u.Installer.Marathon
+	log := logger(u.Job)
+
+	if pkg.Uninstall.Marathon {
+		timeout := waitTimeout(pkg.Install)
+		resources := resources(pkg)
+		for n := len(resources) - 1; n >= 0; n-- {
+			r := resources[n]
+			app := log.WithField("app", r.id())
+
+			app.Info("Deleting from Marathon")
+			var deployment *marathon.DeploymentID
+			err := call("marathon.delete "+r.id(), func() (err error) {
+				deployment, err = r.remove(client)
+				return err
+			})
+			if notFound(err) {
+				continue
+			}
+			if err == nil && deployment != nil {
+				err = call("marathon.wait "+r.id(), func() error {
+					return undeployed(ctx, client, deployment.DeploymentID, time.Now().Add(timeout))
+				})
+			}
+			if err != nil {
+				app.WithError(err).Error("Could not delete from Marathon")
+				return err
+			}
+		}
+
+		// the frameworks are shut down after their schedulers are deleted,
+		// otherwise their tasks are left behind
+		for _, framework := range pkg.Install.Frameworks {
+			teardown := log.WithField("framework", framework.Name)
+
+			teardown.Info("Shutting down the Mesos framework")
+			err := call("mesos.teardown "+framework.Name, func() error {
+				return u.Installer.TeardownFramework(framework.Name)
+			})
+			if err != nil {
+				teardown.WithError(err).Error("Could not shut down the Mesos framework")
 				return err
 			}
 		}
 	}
 
+	if pkg.Uninstall.Chronos {
+		// the jobs of a package, that is installed already, are deleted anyway
+		jobs, err := installer.SortChronosJobs(pkg.Chronos)
+		if err != nil {
+			log.WithError(err).Warn("Could not sort the Chronos jobs, deleting them in the order of the package")
+			jobs = pkg.Chronos
+		}
+
+		// the dependent jobs before their parents
+		for n := len(jobs) - 1; n >= 0; n-- {
+			chronos := jobs[n]
+			job := log.WithField("chronos_job", chronos.Name)
+
+			job.Info("Deleting the Chronos job")
+			err := call("chronos.delete "+chronos.Name, func() error {
+				return u.Installer.DeleteChronosJob(chronos.Name)
+			})
+			if err != nil {
+				job.WithError(err).Error("Could not delete the Chronos job")
+				return err
+			}
+		}
+	}
+
+	if pkg.Uninstall.Metronome && len(pkg.Metronome) > 0 {
+		client := u.Installer.Metronome
+		if client == nil {
+			return installer.ErrNoMetronome(u.Job.Request.Name)
+		}
+
+		for _, metronome := range pkg.Metronome {
+			job := log.WithField("metronome_job", metronome.ID)
+
+			job.Info("Deleting the Metronome job")
+			err := call("metronome.delete "+metronome.ID, func() error {
+				return deleteMetronome(client, metronome.ID)
+			})
+			if err != nil {
+				job.WithError(err).Error("Could not delete the Metronome job")
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// chronosJobs validates the Chronos jobs of a package, and returns them in the
+// order they depend on each other, the bootstrap jobs must be part of the package
+func chronosJobs(pkg *provider.Package) ([]chronos.Job, error) {
+	jobs, err := installer.SortChronosJobs(pkg.Chronos)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool, len(jobs))
+	for _, job := range jobs {
+		names[job.Name] = true
+	}
+
+	for _, name := range pkg.Install.Run {
+		if !names[name] {
+			return nil, installer.ErrNoChronosJob(name)
+		}
+	}
+
+	return jobs, nil
+}
+
+// deleteMetronome deletes a Metronome job, a missing job is deleted already
+func deleteMetronome(client *metronome.Metronome, id string) error {
+	if err := client.DeleteJob(id); err != nil && !metronome.NotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+// logger returns the logger of a job, its entries carry
+// the id of the job and of the request that queued it
+func logger(job *Job) *log.Entry {
+	if job == nil {
+		return log.NewEntry(cfg.Log)
+	}
+
+	return cfg.Log.WithFields(log.Fields{
+		"job":        job.ID,
+		"request_id": job.RequestID,
+		"kind":       job.Kind,
+		"universe":   job.Request.Universe,
+		"package":    job.Request.Name,
+		"revision":   job.Request.Revision,
+	})
+}
+
+// finished logs the outcome of a job
+func finished(job *Job, err error) {
+	if _, ok := err.(ErrCanceled); ok {
+		logger(job).Warn("Job canceled")
+		return
+	}
+
+	if err != nil {
+		logger(job).WithError(err).Error("Job failed")
+		return
+	}
+
+	logger(job).Info("Job succeeded")
+}
+
+// key returns the universe and the package of the work, which are serialized
+func key(work interface{}) string {
+	job := jobOf(work)
+	if job == nil {
+		return ""
+	}
+
+	return job.Request.Universe + "/" + job.Request.Name
+}
+
+// jobOf returns the job of the work
+func jobOf(work interface{}) *Job {
+	switch w := work.(type) {
+	case *Install:
+		return w.Job
+	case *Uninstall:
+		return w.Job
+	}
+
 	return nil
 }
 
-func uninstall(i *Uninstall) error {
+// frameworks checks that the Mesos frameworks of a package are launched by its Marathon apps, pods or groups
+func frameworks(pkg *provider.Package) error {
+	if len(pkg.Install.Frameworks) == 0 {
+		return nil
+	}
+
+	ids := make(map[string]bool)
+	for _, r := range resources(pkg) {
+		ids[absolute(r.id(), "")] = true
+	}
+
+	for n := range pkg.Groups {
+		for _, id := range apps(&pkg.Groups[n], "") {
+			ids[id] = true
+		}
+	}
+
+	for _, framework := range pkg.Install.Frameworks {
+		if !ids[absolute(framework.App, "")] {
+			return ErrNoApp{App: framework.App, Framework: framework.Name}
+		}
+	}
 
 	return nil
 }
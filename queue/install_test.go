@@ -0,0 +1,237 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue_test
+
+import (
+	"time"
+
+	marathon "github.com/gambol99/go-marathon"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/axelspringer/moppi/cfg"
+	"github.com/axelspringer/moppi/mesos"
+	"github.com/axelspringer/moppi/provider"
+	"github.com/axelspringer/moppi/queue"
+)
+
+var _ = Describe("Install", func() {
+	var (
+		c    *cluster
+		jobs *queue.Jobs
+		pkg  *provider.Package
+	)
+
+	BeforeEach(func() {
+		c = newCluster()
+		_, jobs = newQueue(c, cfg.Retry{Attempts: 1})
+
+		checks := &[]marathon.HealthCheck{{}}
+		pkg = &provider.Package{
+			Marathon: []marathon.Application{{ID: "/db", HealthChecks: checks}, {ID: "/web", HealthChecks: checks}},
+			Install:  provider.Install{Marathon: true},
+		}
+	})
+
+	AfterEach(func() {
+		c.Close()
+	})
+
+	It("deploys nothing, when the apps do not fit into the cluster", func() {
+		pkg.Marathon[1].CPUs = 128
+
+		job := finish(jobs, installJob(c, pkg).ID)
+		Expect(job.State).To(Equal(queue.StateFailed))
+		Expect(job.Error).To(ContainSubstring("/web can never be scheduled"))
+		Expect(c.Trace("POST /v2/")).To(BeEmpty())
+	})
+
+	It("deploys nothing, when the pods do not fit into the cluster", func() {
+		pkg.Marathon = nil
+		pkg.Pods = []marathon.Pod{{ID: "/cache", Containers: []*marathon.PodContainer{{Name: "cache", Resources: &marathon.Resources{Cpus: 128}}}}}
+
+		job := finish(jobs, installJob(c, pkg).ID)
+		Expect(job.State).To(Equal(queue.StateFailed))
+		Expect(job.Error).To(ContainSubstring("/cache can never be scheduled"))
+		Expect(c.Trace("POST /v2/")).To(BeEmpty())
+	})
+
+	Describe("the wait on Marathon", func() {
+		It("deploys all apps, before it waits on them", func() {
+			Expect(finish(jobs, installJob(c, pkg).ID).State).To(Equal(queue.StateSucceeded))
+			Expect(c.Trace("POST /v2/apps", "GET /v2/apps/")).To(Equal([]string{
+				"POST /v2/apps",
+				"POST /v2/apps",
+				"GET /v2/apps/db",
+				"GET /v2/apps/web",
+			}))
+		})
+
+		It("deploys an app of an ordered install, after the app before is healthy", func() {
+			pkg.Install.Ordered = true
+			pkg.Install.Wait = provider.WaitHealthy
+
+			Expect(finish(jobs, installJob(c, pkg).ID).State).To(Equal(queue.StateSucceeded))
+			Expect(c.Trace("POST /v2/apps", "GET /v2/apps/")).To(Equal([]string{
+				"POST /v2/apps",
+				"GET /v2/apps/db", // deployed
+				"GET /v2/apps/db", // healthy
+				"POST /v2/apps",
+				"GET /v2/apps/web",
+				"GET /v2/apps/web",
+			}))
+		})
+
+		It("does not wait on the health checks by default", func() {
+			c.unhealthy = true
+
+			Expect(finish(jobs, installJob(c, pkg).ID).State).To(Equal(queue.StateSucceeded))
+		})
+
+		It("waits until the instances pass their health checks", func() {
+			pkg.Install.Wait = provider.WaitHealthy
+			pkg.Install.Timeout = 30
+			c.unhealthy = true
+
+			id := installJob(c, pkg).ID
+			Eventually(func() []string { return c.Calls("GET /v2/apps/db") }, 5*time.Second).Should(HaveLen(2))
+			Consistently(func() string { job, _ := jobs.Get(id); return job.State }).Should(Equal(queue.StateRunning))
+
+			c.mu.Lock()
+			c.unhealthy = false
+			c.mu.Unlock()
+			Expect(finish(jobs, id).State).To(Equal(queue.StateSucceeded))
+		})
+
+		It("fails, when the instances are not healthy in time", func() {
+			pkg.Install.Wait = provider.WaitHealthy
+			pkg.Install.Timeout = 1
+			c.unhealthy = true
+
+			job := finish(jobs, installJob(c, pkg).ID)
+			Expect(job.State).To(Equal(queue.StateFailed))
+			Expect(job.Error).To(Equal(queue.ErrUnhealthy("/db").Error()))
+		})
+
+		It("fails, when the deployment does not finish in time", func() {
+			instances := 1
+			pkg.Marathon[0].Instances = &instances
+			pkg.Install.Timeout = 1
+			c.stalled = true
+
+			job := finish(jobs, installJob(c, pkg).ID)
+			Expect(job.State).To(Equal(queue.StateFailed))
+			Expect(history(job, "marathon.wait /db")).To(HaveLen(1))
+			Expect(history(job, "marathon.wait /web")).To(BeEmpty())
+		})
+	})
+
+	Describe("pods and groups", func() {
+		BeforeEach(func() {
+			pkg.Marathon = []marathon.Application{{ID: "/web"}}
+			pkg.Pods = []marathon.Pod{{ID: "/cache"}}
+			pkg.Groups = []marathon.Group{{ID: "/shop", Apps: []*marathon.Application{{ID: "/shop/db"}}}}
+			pkg.Uninstall = provider.Uninstall{Marathon: true}
+		})
+
+		It("deploys the apps, pods and groups in this order, and waits on them", func() {
+			Expect(finish(jobs, installJob(c, pkg).ID).State).To(Equal(queue.StateSucceeded))
+			Expect(c.Trace("POST /v2/")).To(Equal([]string{"POST /v2/apps", "POST /v2/pods", "POST /v2/groups"}))
+			Expect(c.Trace("GET /v2/")).To(Equal([]string{
+				"GET /v2/apps/web",
+				"GET /v2/pods/cache::status",
+				"GET /v2/groups/shop",
+				"GET /v2/apps/shop/db",
+			}))
+			Expect(c.pods).To(HaveKey("/cache"))
+			Expect(c.apps).To(HaveKey("/shop/db"))
+		})
+
+		It("updates the pods and groups, that exist", func() {
+			pkg.Install.Update = true
+			c.pods["/cache"] = &marathon.Pod{ID: "/cache"}
+			c.groups["/shop"] = &marathon.Group{ID: "/shop"}
+
+			Expect(finish(jobs, installJob(c, pkg).ID).State).To(Equal(queue.StateSucceeded))
+			Expect(c.Trace("POST /v2/", "PUT /v2/")).To(Equal([]string{"POST /v2/apps", "PUT /v2/pods/cache", "PUT /v2/groups/shop"}))
+		})
+
+		It("deletes the groups, pods and apps in the reverse order of their installment", func() {
+			Expect(finish(jobs, installJob(c, pkg).ID).State).To(Equal(queue.StateSucceeded))
+
+			Expect(finish(jobs, uninstallJob(c, pkg).ID).State).To(Equal(queue.StateSucceeded))
+			Expect(c.Trace("DELETE /v2/")).To(Equal([]string{"DELETE /v2/groups/shop", "DELETE /v2/pods/cache", "DELETE /v2/apps/web"}))
+			Expect(c.apps).To(BeEmpty())
+			Expect(c.pods).To(BeEmpty())
+			Expect(c.groups).To(BeEmpty())
+		})
+
+		It("skips the apps, pods and groups, that are deleted already", func() {
+			Expect(finish(jobs, uninstallJob(c, pkg).ID).State).To(Equal(queue.StateSucceeded))
+			Expect(c.Trace("DELETE /v2/")).To(HaveLen(3))
+		})
+	})
+
+	Describe("Mesos frameworks", func() {
+		BeforeEach(func() {
+			pkg.Marathon = []marathon.Application{{ID: "/spark"}}
+			pkg.Install.Frameworks = []provider.Framework{{App: "/spark", Name: "spark"}}
+			pkg.Uninstall = provider.Uninstall{Marathon: true}
+		})
+
+		It("waits until the framework, that an app launches, is active", func() {
+			pkg.Install.Timeout = 30
+
+			id := installJob(c, pkg).ID
+			Eventually(func() []string { return c.Calls("GET /master/state") }, 5*time.Second).Should(HaveLen(2)) // capacity, framework
+			Consistently(func() string { job, _ := jobs.Get(id); return job.State }).Should(Equal(queue.StateRunning))
+
+			c.mu.Lock()
+			c.frameworks = []*mesos.Framework{{ID: "spark-1", Name: "spark", Active: true}}
+			c.mu.Unlock()
+			Expect(finish(jobs, id).State).To(Equal(queue.StateSucceeded))
+		})
+
+		It("fails, when the framework is not active in time", func() {
+			pkg.Install.Timeout = 1
+			c.frameworks = []*mesos.Framework{{ID: "spark-1", Name: "spark"}}
+
+			job := finish(jobs, installJob(c, pkg).ID)
+			Expect(job.State).To(Equal(queue.StateFailed))
+			Expect(job.Error).To(Equal(queue.ErrFramework("spark").Error()))
+		})
+
+		It("rejects a framework, that no app of the package launches", func() {
+			pkg.Install.Frameworks[0].App = "/kafka"
+
+			job := finish(jobs, installJob(c, pkg).ID)
+			Expect(job.State).To(Equal(queue.StateFailed))
+			Expect(job.Error).To(Equal(queue.ErrNoApp{App: "/kafka", Framework: "spark"}.Error()))
+			Expect(c.Trace("")).To(BeEmpty())
+		})
+
+		It("tears the framework down, after its scheduler app is deleted", func() {
+			c.apps["/spark"] = &marathon.Application{ID: "/spark"}
+			c.frameworks = []*mesos.Framework{{ID: "spark-1", Name: "spark", Active: true}}
+
+			Expect(finish(jobs, uninstallJob(c, pkg).ID).State).To(Equal(queue.StateSucceeded))
+			Expect(c.Trace("DELETE /v2/apps/", "POST /master/teardown")).To(Equal([]string{
+				"DELETE /v2/apps/spark",
+				"POST /master/teardown",
+			}))
+			Expect(c.frameworks).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,208 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+)
+
+// NewJobs creates a new registry of jobs
+func NewJobs() *Jobs {
+	return &Jobs{
+		jobs: make(map[string]*Job),
+	}
+}
+
+// Subscribe adds a listener, which is notified about every state transition
+func (j *Jobs) Subscribe(listener Listener) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.listeners = append(j.listeners, listener)
+}
+
+// Add adds a new queued job and returns a copy of it
+func (j *Jobs) Add(job *Job) Job {
+	j.mu.Lock()
+	job.ID = newID()
+	job.State = StateQueued
+	job.Created = time.Now().UTC()
+
+	j.jobs[job.ID] = job
+	j.ids = append(j.ids, job.ID)
+	j.prune()
+	j.mu.Unlock()
+
+	return j.notify(job)
+}
+
+// Get returns a copy of a job
+func (j *Jobs) Get(id string) (Job, bool) {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	job, ok := j.jobs[id]
+	if !ok {
+		return Job{}, false
+	}
+
+	return *job, true
+}
+
+// List returns copies of all known jobs in the order they were added
+func (j *Jobs) List() []Job {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	jobs := make([]Job, 0, len(j.ids))
+	for _, id := range j.ids {
+		jobs = append(jobs, *j.jobs[id])
+	}
+
+	return jobs
+}
+
+// Start marks a job as running
+func (j *Jobs) Start(job *Job) {
+	if job == nil {
+		return
+	}
+
+	j.mu.Lock()
+	now := time.Now().UTC()
+	job.State = StateRunning
+	job.Started = &now
+	j.mu.Unlock()
+
+	j.notify(job)
+}
+
+// Finish marks a job as succeeded, or as failed with the error
+func (j *Jobs) Finish(job *Job, err error) {
+	if job == nil {
+		return
+	}
+
+	j.mu.Lock()
+	if job.Finished != nil { // interrupted
+		j.mu.Unlock()
+		return
+	}
+
+	now := time.Now().UTC()
+	job.State = StateSucceeded
+	job.Finished = &now
+	if err != nil {
+		job.State = StateFailed
+		job.Error = err.Error()
+	}
+
+	if _, ok := err.(ErrCanceled); ok {
+		job.State = StateCanceled
+	}
+	j.mu.Unlock()
+
+	j.notify(job)
+}
+
+// Record adds an attempt of a call to the history of a job
+func (j *Jobs) Record(job *Job, attempt Attempt) {
+	if job == nil {
+		return
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	job.History = append(job.History, attempt)
+}
+
+// Running returns the number of running jobs
+func (j *Jobs) Running() int {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	running := 0
+	for _, job := range j.jobs {
+		if job.State == StateRunning {
+			running++
+		}
+	}
+
+	return running
+}
+
+// Interrupt marks the queued and running jobs as interrupted, and returns copies of them
+func (j *Jobs) Interrupt() []Job {
+	j.mu.Lock()
+	now := time.Now().UTC()
+	interrupted := make([]*Job, 0)
+	for _, id := range j.ids {
+		job := j.jobs[id]
+		if job.State != StateQueued && job.State != StateRunning {
+			continue
+		}
+
+		job.State = StateInterrupted
+		job.Error = ErrInterrupted(job.ID).Error()
+		job.Finished = &now
+		interrupted = append(interrupted, job)
+	}
+	j.mu.Unlock()
+
+	jobs := make([]Job, 0, len(interrupted))
+	for _, job := range interrupted {
+		jobs = append(jobs, j.notify(job))
+	}
+
+	return jobs
+}
+
+// notify notifies the listeners with a copy of the job
+func (j *Jobs) notify(job *Job) Job {
+	j.mu.RLock()
+	snapshot := *job
+	listeners := j.listeners
+	j.mu.RUnlock()
+
+	for _, listener := range listeners {
+		listener(snapshot)
+	}
+
+	return snapshot
+}
+
+// prune drops the oldest finished jobs beyond the retention
+func (j *Jobs) prune() {
+	for len(j.ids) > jobsRetention {
+		job := j.jobs[j.ids[0]]
+		if job.Finished == nil {
+			return
+		}
+
+		delete(j.jobs, job.ID)
+		j.ids = j.ids[1:]
+	}
+}
+
+// newID returns a random job id
+func newID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
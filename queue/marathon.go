@@ -0,0 +1,284 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue
+
+import (
+	"path"
+	"strings"
+
+	"github.com/axelspringer/moppi/installer"
+	"github.com/axelspringer/moppi/provider"
+	marathon "github.com/gambol99/go-marathon"
+)
+
+// resource is a Marathon app, pod or group of a package
+type resource interface {
+	// id returns the id of the resource in Marathon
+	id() string
+	// deploy creates the resource, or updates it, when it exists,
+	// it returns if the resource is created, even when the call fails
+	deploy(client marathon.Marathon, update bool) (bool, error)
+	// exists checks if the resource exists
+	exists(client marathon.Marathon) (bool, error)
+	// running checks that the resource is deployed, and all its instances are running
+	running(client marathon.Marathon) bool
+	// healthy checks that all instances of the resource are healthy
+	healthy(client marathon.Marathon) (bool, error)
+	// remove deletes the resource, and returns its deployment
+	remove(client marathon.Marathon) (*marathon.DeploymentID, error)
+}
+
+// app is a Marathon app of a package
+type app marathon.Application
+
+// pod is a Marathon pod of a package
+type pod marathon.Pod
+
+// group is a Marathon group of a package
+type group marathon.Group
+
+// resources returns the Marathon apps, pods and groups of a package, in this order
+func resources(pkg *provider.Package) []resource {
+	resources := make([]resource, 0, len(pkg.Marathon)+len(pkg.Pods)+len(pkg.Groups))
+	for n := range pkg.Marathon {
+		resources = append(resources, (*app)(&pkg.Marathon[n]))
+	}
+
+	for n := range pkg.Pods {
+		resources = append(resources, (*pod)(&pkg.Pods[n]))
+	}
+
+	for n := range pkg.Groups {
+		resources = append(resources, (*group)(&pkg.Groups[n]))
+	}
+
+	return resources
+}
+
+// marathonApps returns the Marathon apps of a package, and of its groups
+func marathonApps(pkg *provider.Package) []*marathon.Application {
+	apps := make([]*marathon.Application, 0, len(pkg.Marathon))
+	for n := range pkg.Marathon {
+		apps = append(apps, &pkg.Marathon[n])
+	}
+
+	for n := range pkg.Groups {
+		apps = append(apps, installer.GroupApps(&pkg.Groups[n])...)
+	}
+
+	return apps
+}
+
+// marathonPods returns the Marathon pods of a package
+func marathonPods(pkg *provider.Package) []*marathon.Pod {
+	pods := make([]*marathon.Pod, 0, len(pkg.Pods))
+	for n := range pkg.Pods {
+		pods = append(pods, &pkg.Pods[n])
+	}
+
+	return pods
+}
+
+// id returns the id of the app
+func (a *app) id() string {
+	return a.ID
+}
+
+// deploy creates the app, or updates it
+func (a *app) deploy(client marathon.Marathon, update bool) (bool, error) {
+	if update {
+		exists, err := client.HasApplication(a.ID)
+		if err != nil {
+			return false, err
+		}
+
+		if exists {
+			_, err := client.UpdateApplication((*marathon.Application)(a), false)
+			return false, err
+		}
+	}
+
+	_, err := client.CreateApplication((*marathon.Application)(a))
+
+	return true, err
+}
+
+// exists checks if the app exists
+func (a *app) exists(client marathon.Marathon) (bool, error) {
+	return client.HasApplication(a.ID)
+}
+
+// running checks that all tasks of the app are running
+func (a *app) running(client marathon.Marathon) bool {
+	app, err := client.Application(a.ID)
+
+	return err == nil && app.AllTaskRunning()
+}
+
+// healthy checks that all tasks of the app pass their health checks
+func (a *app) healthy(client marathon.Marathon) (bool, error) {
+	return client.ApplicationOK(a.ID)
+}
+
+// remove deletes the app
+func (a *app) remove(client marathon.Marathon) (*marathon.DeploymentID, error) {
+	return client.DeleteApplication(a.ID, false)
+}
+
+// id returns the id of the pod
+func (p *pod) id() string {
+	return p.ID
+}
+
+// deploy creates the pod, or updates it
+func (p *pod) deploy(client marathon.Marathon, update bool) (bool, error) {
+	if update {
+		_, err := client.Pod(p.ID)
+		if err == nil {
+			_, err := client.UpdatePod((*marathon.Pod)(p), false)
+			return false, err
+		}
+
+		if !notFound(err) {
+			return false, err
+		}
+	}
+
+	_, err := client.CreatePod((*marathon.Pod)(p))
+
+	return true, err
+}
+
+// exists checks if the pod exists
+func (p *pod) exists(client marathon.Marathon) (bool, error) {
+	_, err := client.Pod(p.ID)
+	if notFound(err) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// running checks that the pod is stable
+func (p *pod) running(client marathon.Marathon) bool {
+	return client.PodIsRunning(p.ID)
+}
+
+// healthy checks that the pod is stable, which its health checks are part of
+func (p *pod) healthy(client marathon.Marathon) (bool, error) {
+	return client.PodIsRunning(p.ID), nil
+}
+
+// remove deletes the pod
+func (p *pod) remove(client marathon.Marathon) (*marathon.DeploymentID, error) {
+	return client.DeletePod(p.ID, false)
+}
+
+// id returns the id of the group
+func (g *group) id() string {
+	return g.ID
+}
+
+// deploy creates the group, or updates it
+func (g *group) deploy(client marathon.Marathon, update bool) (bool, error) {
+	if update {
+		exists, err := client.HasGroup(g.ID)
+		if err != nil {
+			return false, err
+		}
+
+		if exists {
+			_, err := client.UpdateGroup(g.ID, (*marathon.Group)(g), false)
+			return false, err
+		}
+	}
+
+	err := client.CreateGroup((*marathon.Group)(g))
+
+	return true, err
+}
+
+// exists checks if the group exists
+func (g *group) exists(client marathon.Marathon) (bool, error) {
+	return client.HasGroup(g.ID)
+}
+
+// running checks that the deployments of all apps of the group are finished, and their tasks are running
+func (g *group) running(client marathon.Marathon) bool {
+	group, err := client.Group(g.ID)
+	if err != nil {
+		return false
+	}
+
+	for _, a := range group.Apps {
+		app, err := client.Application(a.ID)
+		if err != nil || !app.AllTaskRunning() || len(app.Deployments) > 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// healthy checks that the tasks of all apps of the group, and its
+// subgroups, pass their health checks
+func (g *group) healthy(client marathon.Marathon) (bool, error) {
+	for _, id := range apps((*marathon.Group)(g), "") {
+		ok, err := client.ApplicationOK(id)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// remove deletes the group with all its apps
+func (g *group) remove(client marathon.Marathon) (*marathon.DeploymentID, error) {
+	return client.DeleteGroup(g.ID, false)
+}
+
+// apps returns the absolute ids of the apps of a group, and its subgroups
+func apps(g *marathon.Group, parent string) []string {
+	id := absolute(g.ID, parent)
+
+	ids := make([]string, 0, len(g.Apps))
+	for _, app := range g.Apps {
+		ids = append(ids, absolute(app.ID, id))
+	}
+
+	for _, group := range g.Groups {
+		ids = append(ids, apps(group, id)...)
+	}
+
+	return ids
+}
+
+// absolute returns the absolute id of an app or a group, relative ids are
+// relative to the group, that contains them
+func absolute(id string, parent string) string {
+	if strings.HasPrefix(id, "/") {
+		return id
+	}
+
+	return path.Join("/", parent, id)
+}
+
+// notFound checks if Marathon answered, that a resource does not exist
+func notFound(err error) bool {
+	apiErr, ok := err.(*marathon.APIError)
+
+	return ok && apiErr.ErrCode == marathon.ErrCodeNotFound
+}
@@ -14,39 +14,224 @@
 
 package queue
 
-import "fmt"
+import (
+	"context"
+	"time"
 
-// New is providing the Queue
-func New(workers int) WorkQueue {
-	return mustNew(workers)
+	"github.com/axelspringer/moppi/cfg"
+)
+
+// New is providing the Queue, the state of the jobs is kept in jobs,
+// at most capacity jobs wait for a worker
+func New(workers int, capacity int, jobs *Jobs) *Queue {
+	return mustNew(workers, capacity, jobs)
 }
 
 // mustNew wraps the creation of a new queue
-func mustNew(workers int) WorkQueue {
-	var queue Queue
-	queue.Worker = make(chan chan interface{}, workers)
-	queue.Work = make(chan interface{}, 100)
+func mustNew(workers int, capacity int, jobs *Jobs) *Queue {
+	queue := &Queue{}
+	queue.Worker = make(chan chan interface{}, maxWorkers)
+	queue.Jobs = jobs
+	queue.busy = make(map[string]bool)
+	queue.running = make(map[string]*running)
+	queue.wake = make(chan struct{}, 1)
+	queue.quit = make(chan struct{})
 
 	// Now, create all of our workers.
-	for i := 0; i < workers; i++ {
-		// TODO: remove or subsitute
-		fmt.Println("Starting worker", i+1)
-		worker := NewWorker(i+1, queue)
+	queue.Limit(capacity)
+	queue.Retry(cfg.Retry{})
+	queue.Resize(workers)
+
+	// Curate the work in a go routine
+	go queue.dispatch()
+
+	return queue
+}
+
+// Enqueue adds work to the queue and registers its job, which is returned,
+// it fails when the queue is full, then no job is registered
+func (q *Queue) Enqueue(work interface{}) (Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.pending) >= q.capacity {
+		return Job{}, ErrFull(q.capacity)
+	}
+
+	var snapshot Job
+	if job := jobOf(work); job != nil {
+		snapshot = q.Jobs.Add(job) // before a worker can start it
+	}
+	q.pending = append(q.pending, work)
+	q.notify()
+
+	return snapshot, nil
+}
+
+// Limit sets the number of jobs, that can wait for a worker
+func (q *Queue) Limit(capacity int) {
+	if capacity < 1 {
+		capacity = DefaultCapacity
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.capacity = capacity
+}
+
+// Capacity returns the number of jobs, that can wait for a worker
+func (q *Queue) Capacity() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.capacity
+}
+
+// Resize starts or retires workers, until the queue has the number of workers,
+// busy workers are retired, when they have finished their work
+func (q *Queue) Resize(workers int) {
+	q.mu.Lock()
+	q.size = workers
+	for q.count < q.size {
+		q.count++
+		q.id++
+		cfg.Log.WithField("worker", q.id).Debug("Starting worker")
+		worker := NewWorker(q.id, q)
 		worker.Start()
 	}
+	q.mu.Unlock()
 
-	// Curate the work in a go routine
-	go func() {
-		for {
-			select {
-			case work := <-queue.Work:
-				go func() {
-					worker := <-queue.Worker
-					worker <- work
-				}()
-			}
-		}
-	}()
-
-	return queue.Work
+	// retire the idle workers
+	for q.retire() {
+		select {
+		case worker := <-q.Worker:
+			worker <- retire{}
+		default:
+			q.mu.Lock()
+			q.count++ // none is idle
+			q.mu.Unlock()
+			q.notify()
+			return
+		}
+	}
+}
+
+// retire checks if a worker should be retired, and counts it as retired
+func (q *Queue) retire() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.count <= q.size {
+		return false
+	}
+	q.count--
+
+	return true
+}
+
+// dispatch hands the pending work to the idle workers, until the queue is stopped
+func (q *Queue) dispatch() {
+	for {
+		var worker chan interface{}
+		select {
+		case worker = <-q.Worker:
+		case <-q.quit:
+			return
+		}
+
+		work := q.wait()
+		if work == nil {
+			return
+		}
+
+		worker <- work
+	}
+}
+
+// wait waits for work, that can be handed to the idle worker,
+// it returns nil, when the queue is stopped
+func (q *Queue) wait() interface{} {
+	for {
+		if q.retire() {
+			return retire{}
+		}
+
+		if work := q.next(); work != nil {
+			return work
+		}
+
+		select {
+		case <-q.wake:
+		case <-q.quit:
+			return nil
+		}
+	}
+}
+
+// next takes the oldest pending work, which package is not busy,
+// marks its package as busy and makes it cancelable
+func (q *Queue) next() interface{} {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for i, work := range q.pending {
+		key := key(work)
+		if key != "" && q.busy[key] {
+			continue
+		}
+
+		if key != "" {
+			q.busy[key] = true
+		}
+		q.pending = append(q.pending[:i], q.pending[i+1:]...)
+
+		if job := jobOf(work); job != nil {
+			ctx, cancel := context.WithCancel(context.Background())
+			q.running[job.ID] = &running{ctx: ctx, cancel: cancel}
+		}
+
+		return work
+	}
+
+	return nil
+}
+
+// done marks the package of the work as not busy anymore
+func (q *Queue) done(work interface{}) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if job := jobOf(work); job != nil {
+		if running, ok := q.running[job.ID]; ok {
+			running.cancel()
+			delete(q.running, job.ID)
+		}
+	}
+	delete(q.busy, key(work))
+	q.notify()
+}
+
+// notify wakes the dispatcher, it does not block
+func (q *Queue) notify() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Stop stops handing work to the workers, and waits for the running jobs
+// to finish, the jobs that are still queued or running after the timeout
+// are interrupted
+func (q *Queue) Stop(timeout time.Duration) {
+	close(q.quit)
+
+	deadline := time.Now().Add(timeout)
+	for q.Jobs.Running() > 0 && time.Now().Before(deadline) {
+		time.Sleep(stopPoll)
+	}
+
+	for _, job := range q.Jobs.Interrupt() {
+		logger(&job).Warn("Job interrupted")
+	}
 }
@@ -0,0 +1,182 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue_test
+
+import (
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	marathon "github.com/gambol99/go-marathon"
+
+	"github.com/axelspringer/moppi/provider"
+	"github.com/axelspringer/moppi/queue"
+)
+
+var _ = Describe("Queue", func() {
+	var (
+		jobs     *queue.Jobs
+		q        *queue.Queue
+		mu       sync.Mutex
+		notified []queue.Job
+	)
+
+	// uninstall is the work of an uninstallment of a package, without a worker it stays queued
+	uninstall := func(name string) *queue.Uninstall {
+		return &queue.Uninstall{
+			Package: &provider.Package{},
+			Job: &queue.Job{
+				Kind:    queue.KindUninstall,
+				Request: provider.Request{Universe: "prod", Name: name},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		notified = nil
+		jobs = queue.NewJobs()
+		jobs.Subscribe(func(job queue.Job) {
+			mu.Lock()
+			defer mu.Unlock()
+			notified = append(notified, job)
+		})
+		q = queue.New(0, 1, jobs)
+	})
+
+	AfterEach(func() {
+		q.Stop(time.Second)
+	})
+
+	It("registers the job of the accepted work", func() {
+		job, err := q.Enqueue(uninstall("nginx"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(job.ID).NotTo(BeEmpty())
+		Expect(job.State).To(Equal(queue.StateQueued))
+
+		Expect(jobs.List()).To(HaveLen(1))
+		mu.Lock()
+		defer mu.Unlock()
+		Expect(notified).To(HaveLen(1))
+	})
+
+	It("leaves no job behind, when the queue is full", func() {
+		_, err := q.Enqueue(uninstall("nginx"))
+		Expect(err).NotTo(HaveOccurred())
+
+		job, err := q.Enqueue(uninstall("redis"))
+		Expect(err).To(Equal(queue.ErrFull(1)))
+		Expect(job.ID).To(BeEmpty())
+
+		Expect(jobs.List()).To(HaveLen(1))
+		Expect(jobs.List()[0].Request.Name).To(Equal("nginx"))
+		mu.Lock()
+		defer mu.Unlock()
+		Expect(notified).To(HaveLen(1)) // nothing audited or published for the rejected job
+	})
+})
+
+var _ = Describe("Serialization", func() {
+	var (
+		c    *cluster
+		jobs *queue.Jobs
+		q    *queue.Queue
+	)
+
+	BeforeEach(func() {
+		c = newCluster()
+		jobs = queue.NewJobs()
+		q = queue.New(2, 10, jobs)
+	})
+
+	AfterEach(func() {
+		q.Stop(time.Second)
+		c.Close()
+	})
+
+	install := func(name string) queue.Job {
+		pkg := &provider.Package{
+			Marathon: []marathon.Application{{ID: "/" + name}},
+			Install:  provider.Install{Marathon: true, Update: true},
+		}
+
+		return enqueue(q, &queue.Install{Universe: "prod", Package: pkg, Installer: c.Installer(), Job: newJob(queue.KindInstall, name)})
+	}
+
+	state := func(id string) string {
+		job, _ := jobs.Get(id)
+		return job.State
+	}
+
+	It("runs one job of a package at a time, and the jobs of other packages meanwhile", func() {
+		release := c.Hold("POST /v2/apps")
+		defer release()
+
+		first := install("nginx")
+		Eventually(func() []string { return c.Calls("POST /v2/apps") }).Should(HaveLen(1))
+
+		second := install("nginx")
+		other := install("redis")
+		Expect(finish(jobs, other.ID).State).To(Equal(queue.StateSucceeded))
+		Expect(state(first.ID)).To(Equal(queue.StateRunning))
+		Expect(state(second.ID)).To(Equal(queue.StateQueued))
+
+		release()
+		done := finish(jobs, first.ID)
+		Expect(done.State).To(Equal(queue.StateSucceeded))
+
+		next := finish(jobs, second.ID)
+		Expect(next.State).To(Equal(queue.StateSucceeded))
+		Expect(next.Started.Before(*done.Finished)).To(BeFalse())
+		Expect(c.Calls("PUT /v2/apps/nginx")).To(HaveLen(1)) // the second job updated the app
+	})
+})
+
+// newJob returns a job of a package
+func newJob(kind string, name string) *queue.Job {
+	return &queue.Job{Kind: kind, Request: provider.Request{Universe: "prod", Name: name}}
+}
+
+// enqueue enqueues work, that must be accepted
+func enqueue(q *queue.Queue, work interface{}) queue.Job {
+	job, err := q.Enqueue(work)
+	Expect(err).NotTo(HaveOccurred())
+
+	return job
+}
+
+// finish waits until a job is finished, and returns it
+func finish(jobs *queue.Jobs, id string) queue.Job {
+	var job queue.Job
+	Eventually(func() *time.Time {
+		job, _ = jobs.Get(id)
+		return job.Finished
+	}, 10*time.Second).ShouldNot(BeNil())
+
+	return job
+}
+
+// history returns the attempts of a call of a job
+func history(job queue.Job, call string) []queue.Attempt {
+	attempts := make([]queue.Attempt, 0)
+	for _, attempt := range job.History {
+		if attempt.Call == call {
+			attempts = append(attempts, attempt)
+		}
+	}
+
+	return attempts
+}
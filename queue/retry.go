@@ -0,0 +1,104 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/axelspringer/moppi/cfg"
+	"github.com/axelspringer/moppi/installer"
+)
+
+// Retry sets the retry policy of the calls to Marathon and Chronos,
+// the unset limits are set to their defaults
+func (q *Queue) Retry(retry cfg.Retry) {
+	if retry.Attempts < 1 {
+		retry.Attempts = DefaultAttempts
+	}
+
+	if retry.Backoff <= 0 {
+		retry.Backoff = DefaultBackoff
+	}
+
+	if retry.MaxBackoff < retry.Backoff {
+		retry.MaxBackoff = DefaultMaxBackoff
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.retry = retry
+}
+
+// caller returns the caller of the operations of a job, every attempt is recorded in
+// the history of the job, and transient failures are retried with an exponential backoff,
+// no operation is called anymore, when the job is canceled
+func (q *Queue) caller(ctx context.Context, job *Job) caller {
+	q.mu.Lock()
+	retry := q.retry
+	q.mu.Unlock()
+
+	return func(call string, op func() error) error {
+		backoff := retry.Backoff
+		for attempt := 1; ; attempt++ {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			start := time.Now()
+			err := op()
+
+			record := Attempt{
+				Call:     call,
+				Attempt:  attempt,
+				Time:     start.UTC(),
+				Duration: float64(time.Since(start)) / float64(time.Millisecond),
+			}
+			if err != nil {
+				record.Error = err.Error()
+				record.Transient = installer.Transient(err)
+			}
+			q.Jobs.Record(job, record)
+
+			if !record.Transient || attempt >= retry.Attempts {
+				return err
+			}
+
+			wait := jitter(backoff)
+			logger(job).WithError(err).WithField("call", call).WithField("attempt", attempt).Warnf("Retrying in %v", wait)
+
+			select {
+			case <-time.After(wait):
+			case <-q.quit:
+				return err
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+
+			if backoff *= 2; backoff > retry.MaxBackoff {
+				backoff = retry.MaxBackoff
+			}
+		}
+	}
+}
+
+// jitter returns a random duration between the half and the whole backoff
+func jitter(backoff time.Duration) time.Duration {
+	half := int64(backoff / 2)
+
+	return time.Duration(half + rand.Int63n(half+1))
+}
@@ -0,0 +1,136 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue_test
+
+import (
+	"net/http"
+	"time"
+
+	chronos "github.com/axelspringer/go-chronos"
+	marathon "github.com/gambol99/go-marathon"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/axelspringer/moppi/cfg"
+	"github.com/axelspringer/moppi/metronome"
+	"github.com/axelspringer/moppi/provider"
+	"github.com/axelspringer/moppi/queue"
+)
+
+var _ = Describe("Retry", func() {
+	var (
+		c    *cluster
+		jobs *queue.Jobs
+		q    *queue.Queue
+	)
+
+	BeforeEach(func() {
+		c = newCluster()
+		q, jobs = newQueue(c, cfg.Retry{Attempts: 3, Backoff: time.Millisecond, MaxBackoff: time.Millisecond})
+	})
+
+	AfterEach(func() {
+		c.Close()
+	})
+
+	web := func() *provider.Package {
+		return &provider.Package{
+			Marathon: []marathon.Application{{ID: "/web"}},
+			Install:  provider.Install{Marathon: true},
+		}
+	}
+
+	backup := func() *provider.Package {
+		return &provider.Package{
+			Metronome: []metronome.Job{{
+				ID:        "backup",
+				Run:       metronome.Run{Cmd: "backup.sh", CPUs: 0.1, Mem: 32},
+				Schedules: []metronome.Schedule{{ID: "nightly", Cron: "0 2 * * *", Enabled: true}},
+			}},
+			Install: provider.Install{Metronome: true},
+		}
+	}
+
+	It("retries a transient failure of Marathon", func() {
+		c.Fail("POST /v2/apps", http.StatusServiceUnavailable, false)
+
+		job := finish(jobs, installJob(c, web()).ID)
+		Expect(job.State).To(Equal(queue.StateSucceeded))
+		Expect(c.Calls("POST /v2/apps")).To(HaveLen(2))
+
+		attempts := history(job, "marathon.deploy /web")
+		Expect(attempts).To(HaveLen(2))
+		Expect(attempts[0].Transient).To(BeTrue())
+		Expect(attempts[1].Error).To(BeEmpty())
+	})
+
+	It("counts an app as created, that a lost call created", func() {
+		pkg := web()
+		pkg.Marathon[0].HealthChecks = &[]marathon.HealthCheck{{}}
+		pkg.Install.Wait = provider.WaitHealthy
+		pkg.Install.Timeout = 60
+		c.unhealthy = true
+		c.Fail("POST /v2/apps", http.StatusServiceUnavailable, true)
+
+		id := installJob(c, pkg).ID
+		Eventually(func() []string { return c.Calls("GET /v2/apps/web") }, 5*time.Second).Should(HaveLen(3)) // exists, wait, healthy
+
+		_, err := q.Cancel(id, true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(finish(jobs, id).State).To(Equal(queue.StateCanceled))
+
+		Expect(c.Calls("POST /v2/apps")).To(HaveLen(1))
+		Expect(c.Calls("DELETE /v2/apps/web")).To(HaveLen(1)) // rolled back
+	})
+
+	It("does not retry a conflict on Chronos", func() {
+		c.Fail("POST /scheduler/iso8601", http.StatusConflict, false)
+
+		job := finish(jobs, installJob(c, &provider.Package{
+			Chronos: []chronos.Job{{Name: "cleanup", Schedule: "R/2017-10-01T02:00:00Z/P1D"}},
+			Install: provider.Install{Chronos: true},
+		}).ID)
+		Expect(job.State).To(Equal(queue.StateFailed))
+		Expect(c.Calls("POST /scheduler/iso8601")).To(HaveLen(1))
+		Expect(history(job, "chronos.deploy cleanup")[0].Transient).To(BeFalse())
+	})
+
+	It("does not retry a conflict on Metronome", func() {
+		c.timers["backup"] = &metronome.Job{ID: "backup"}
+
+		job := finish(jobs, installJob(c, backup()).ID)
+		Expect(job.State).To(Equal(queue.StateFailed))
+		Expect(c.Calls("POST /v1/jobs")).To(HaveLen(1))
+		Expect(history(job, "metronome.deploy backup")[0].Transient).To(BeFalse())
+	})
+
+	It("completes a Metronome job, that a failed attempt created", func() {
+		c.Fail("POST /v1/jobs/backup/schedules", http.StatusServiceUnavailable, false)
+
+		job := finish(jobs, installJob(c, backup()).ID)
+		Expect(job.State).To(Equal(queue.StateSucceeded))
+		Expect(c.Calls("POST /v1/jobs")).To(HaveLen(1))
+		Expect(c.timers["backup"].Schedules).To(HaveLen(1))
+	})
+
+	It("counts a Metronome job as created, that a lost call created", func() {
+		c.Fail("POST /v1/jobs", http.StatusServiceUnavailable, true)
+
+		job := finish(jobs, installJob(c, backup()).ID)
+		Expect(job.State).To(Equal(queue.StateSucceeded))
+		Expect(c.Calls("POST /v1/jobs")).To(HaveLen(1))
+		Expect(c.timers["backup"].Schedules).To(HaveLen(1))
+	})
+})
@@ -14,23 +14,55 @@
 
 package queue
 
-import "github.com/axelspringer/moppi/provider"
-import "github.com/axelspringer/moppi/installer"
+import (
+	"context"
+	"sync"
+	"time"
 
-// Queue describes a queue
+	"github.com/axelspringer/moppi/cfg"
+	"github.com/axelspringer/moppi/events"
+	"github.com/axelspringer/moppi/installer"
+	"github.com/axelspringer/moppi/provider"
+	"github.com/axelspringer/moppi/signing"
+)
+
+// Queue describes a queue, the work waits in pending until an idle
+// worker takes it, and no other work on its package is running
 type Queue struct {
-	Work   WorkQueue
-	Worker WorkerQueue
+	Worker   WorkerQueue
+	Jobs     *Jobs
+	pending  []interface{}
+	busy     map[string]bool
+	running  map[string]*running
+	capacity int
+	retry    cfg.Retry
+	wake     chan struct{}
+	quit     chan struct{}
+	size     int
+	count    int
+	id       int
+	mu       sync.Mutex
 }
 
 // Worker is describing a worker to which work can be send
 type Worker struct {
-	ID       int
-	Work     WorkQueue
-	Worker   WorkerQueue
-	QuitChan chan bool
+	ID     int
+	Work   WorkQueue
+	Worker WorkerQueue
+	Jobs   *Jobs
+	queue  *Queue
 }
 
+// running is the cancellation of a job, that was handed to a worker
+type running struct {
+	ctx      context.Context
+	cancel   context.CancelFunc
+	rollback bool
+}
+
+// retire is handed to an idle worker, that is retired
+type retire struct{}
+
 // WorkQueue describes the queue for the work
 type WorkQueue chan interface{}
 
@@ -39,12 +71,63 @@ type WorkerQueue chan chan interface{}
 
 // Install describes an installment
 type Install struct {
+	Universe  string
 	Package   *provider.Package
+	Revision  *provider.Revision
 	Installer *installer.Installer
+	Verifier  *signing.Verifier
+	Events    *events.Bus
+	Job       *Job
+
+	deployed  []resource // deployed Marathon apps, pods and groups
+	created   []resource // created Marathon apps, pods and groups
+	jobs      []string   // created Chronos jobs
+	metronome []string   // created Metronome jobs
 }
 
 // Uninstall describes an uninstallment
 type Uninstall struct {
 	Package   *provider.Package
 	Installer *installer.Installer
+	Job       *Job
+}
+
+// Job describes the state of an installment or uninstallment
+type Job struct {
+	ID        string           `json:"id"`
+	Kind      string           `json:"kind"`
+	Request   provider.Request `json:"request"`
+	Identity  string           `json:"identity"`
+	SourceIP  string           `json:"source_ip"`
+	RequestID string           `json:"request_id,omitempty"`
+	State     string           `json:"state"`
+	Error     string           `json:"error,omitempty"`
+	Created   time.Time        `json:"created"`
+	Started   *time.Time       `json:"started,omitempty"`
+	Finished  *time.Time       `json:"finished,omitempty"`
+	History   []Attempt        `json:"history,omitempty"`
+}
+
+// Attempt describes an attempt of a call to Marathon or Chronos of a job
+type Attempt struct {
+	Call      string    `json:"call"`
+	Attempt   int       `json:"attempt"`
+	Time      time.Time `json:"time"`
+	Duration  float64   `json:"duration_ms"`
+	Error     string    `json:"error,omitempty"`
+	Transient bool      `json:"transient,omitempty"`
+}
+
+// caller calls an operation of a job on Marathon or Chronos
+type caller func(call string, op func() error) error
+
+// Listener is notified about every state transition of a job
+type Listener func(job Job)
+
+// Jobs keeps track of the jobs in the queue
+type Jobs struct {
+	jobs      map[string]*Job
+	ids       []string
+	listeners []Listener
+	mu        sync.RWMutex
 }
@@ -14,18 +14,19 @@
 
 package queue
 
-import "fmt"
+import "github.com/axelspringer/moppi/cfg"
 
 // NewWorker creates, and returns a new Worker object. Its only argument
 // is a channel that the worker can add itself to whenever it is done its
 // work.
-func NewWorker(id int, queue Queue) Worker {
+func NewWorker(id int, queue *Queue) Worker {
 	// Create, and return the worker.
 	worker := Worker{
-		ID:       id,
-		Work:     make(WorkQueue),
-		Worker:   queue.Worker,
-		QuitChan: make(chan bool)}
+		ID:     id,
+		Work:   make(WorkQueue),
+		Worker: queue.Worker,
+		Jobs:   queue.Jobs,
+		queue:  queue}
 
 	return worker
 }
@@ -35,37 +36,58 @@ func (w *Worker) Start() {
 	go func() {
 		for {
 			// Add ourselves into the worker queue.
-			w.Worker <- w.Work
+			select {
+			case w.Worker <- w.Work:
+			case <-w.queue.quit:
+				return
+			}
 
 			select {
 			case work := <-w.Work:
+				// do not start work, when the queue is stopped
+				select {
+				case <-w.queue.quit:
+					return
+				default:
+				}
+
 				switch work.(type) {
+				case retire:
+					cfg.Log.WithField("worker", w.ID).Debug("Retiring worker")
+					return
 				case *Install:
-					// TODO: error handling
-					err := install(work.(*Install))
-					if err != nil {
-						fmt.Println(err)
+					i := work.(*Install)
+					w.Jobs.Start(i.Job)
+					logger(i.Job).WithField("worker", w.ID).Info("Installing the package")
+
+					ctx := w.queue.context(i.Job)
+					err := install(ctx, i, w.queue.caller(ctx, i.Job))
+					if err != nil && ctx.Err() != nil {
+						err = ErrCanceled(i.Job.ID)
+						i.abort(w.queue.rollback(i.Job))
 					}
+					finished(i.Job, err)
+					w.Jobs.Finish(i.Job, err)
+					w.queue.done(work)
 				case *Uninstall:
-					// TODO: error handling
-					err := uninstall(work.(*Uninstall))
-					if err != nil {
-						fmt.Println(err)
+					u := work.(*Uninstall)
+					w.Jobs.Start(u.Job)
+					logger(u.Job).WithField("worker", w.ID).Info("Uninstalling the package")
+
+					ctx := w.queue.context(u.Job)
+					err := uninstall(ctx, u, w.queue.caller(ctx, u.Job))
+					if err != nil && ctx.Err() != nil {
+						err = ErrCanceled(u.Job.ID)
 					}
+					finished(u.Job, err)
+					w.Jobs.Finish(u.Job, err)
+					w.queue.done(work)
 				default:
 					break
 				}
-			case <-w.QuitChan:
+			case <-w.queue.quit:
 				return
 			}
 		}
 	}()
 }
-
-// Stop tells the worker to stop listening for work requests.
-// Note that the worker will only stop *after* it has finished its work.
-func (w *Worker) Stop() {
-	go func() {
-		w.QuitChan <- true
-	}()
-}
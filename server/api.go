@@ -0,0 +1,529 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	moppi "github.com/axelspringer/moppi/api/v1"
+	"github.com/axelspringer/moppi/audit"
+	"github.com/axelspringer/moppi/auth"
+	"github.com/axelspringer/moppi/cfg"
+	"github.com/axelspringer/moppi/provider"
+	"github.com/axelspringer/moppi/queue"
+	"github.com/axelspringer/moppi/signing"
+)
+
+// serveGRPC serves the gRPC api on its own listener
+func (server *Server) serveGRPC() {
+	listener, err := net.Listen("tcp", server.grpcListen)
+	if err != nil {
+		cfg.Log.WithError(err).Errorf("Could not listen for gRPC on %v", server.grpcListen)
+		return
+	}
+
+	if err := server.grpc.Serve(listener); err != nil {
+		cfg.Log.WithError(err).Error("Stopped serving gRPC")
+	}
+}
+
+// newGRPC returns the gRPC server of the api
+func newGRPC(api *api) *grpc.Server {
+	server := grpc.NewServer()
+	moppi.RegisterUniversesServer(server, api)
+	moppi.RegisterPackagesServer(server, api)
+	moppi.RegisterInstallsServer(server, api)
+	moppi.RegisterJobsServer(server, api)
+
+	return server
+}
+
+// gateway returns the REST gateway of the gRPC api, it calls the api in process
+func (server *Server) gateway(api *api) (http.Handler, error) {
+	ctx := context.Background()
+	mux := runtime.NewServeMux(runtime.WithIncomingHeaderMatcher(matchHeader))
+
+	for _, register := range []func() error{
+		func() error { return moppi.RegisterUniversesHandlerServer(ctx, mux, api) },
+		func() error { return moppi.RegisterPackagesHandlerServer(ctx, mux, api) },
+		func() error { return moppi.RegisterInstallsHandlerServer(ctx, mux, api) },
+		func() error { return moppi.RegisterJobsHandlerServer(ctx, mux, api) },
+	} {
+		if err := register(); err != nil {
+			return nil, err
+		}
+	}
+
+	return mux, nil
+}
+
+// matchHeader passes along the request id, and the headers passed by default
+func matchHeader(key string) (string, bool) {
+	if key == http.CanonicalHeaderKey(requestIDHeader) {
+		return requestIDMeta, true
+	}
+
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// ListUniverses returns all the known universes, the caller can read
+func (a *api) ListUniverses(ctx context.Context, req *moppi.ListUniversesRequest) (*moppi.ListUniversesResponse, error) {
+	identity, err := a.identify(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	universes, err := a.server.provider.GetUniverses()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	res := &moppi.ListUniversesResponse{}
+	for _, universe := range *universes {
+		id := path.Base(universe.Href)
+		if identity.Can(id, auth.Read) {
+			res.Universes = append(res.Universes, toUniverse(id, &universe))
+		}
+	}
+
+	return res, nil
+}
+
+// GetUniverse returns a universe
+func (a *api) GetUniverse(ctx context.Context, req *moppi.GetUniverseRequest) (*moppi.Universe, error) {
+	if _, err := a.allow(ctx, req.Id, auth.Read); err != nil {
+		return nil, err
+	}
+
+	universe, err := a.server.provider.GetUniverse(&provider.Request{Universe: req.Id})
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	return toUniverse(req.Id, universe), nil
+}
+
+// CreateUniverse is creating a new universe
+func (a *api) CreateUniverse(ctx context.Context, req *moppi.CreateUniverseRequest) (*moppi.Universe, error) {
+	if req.Universe == nil {
+		return nil, status.Error(codes.InvalidArgument, "Please send a universe")
+	}
+
+	universe := provider.Universe{
+		Name:        req.Universe.Name,
+		Description: req.Universe.Description,
+		Version:     req.Universe.Version,
+	}
+	id := strings.ToLower(universe.Name)
+	entry := newAuditEntry("universe.create", "CreateUniverse", &provider.Request{Universe: id})
+
+	identity, err := a.allow(ctx, id, auth.Admin)
+	if err != nil {
+		return nil, a.audit(ctx, entry, identity, err)
+	}
+
+	if err := a.server.validator.Struct(universe); err != nil {
+		return nil, a.audit(ctx, entry, identity, status.Error(codes.InvalidArgument, err.Error()))
+	}
+
+	if err := a.server.provider.CreateUniverse(&universe); err != nil {
+		return nil, a.audit(ctx, entry, identity, status.Error(codes.Internal, err.Error()))
+	}
+
+	return toUniverse(id, &universe), a.audit(ctx, entry, identity, nil)
+}
+
+// DeleteUniverse is deleting a universe
+func (a *api) DeleteUniverse(ctx context.Context, req *moppi.DeleteUniverseRequest) (*moppi.Empty, error) {
+	pkgRequest := &provider.Request{Universe: req.Id}
+	entry := newAuditEntry("universe.delete", "DeleteUniverse", pkgRequest)
+
+	identity, err := a.allow(ctx, req.Id, auth.Admin)
+	if err != nil {
+		return nil, a.audit(ctx, entry, identity, err)
+	}
+
+	if err := a.server.provider.DeleteUniverse(pkgRequest); err != nil {
+		return nil, a.audit(ctx, entry, identity, status.Error(codes.Internal, err.Error()))
+	}
+
+	return &moppi.Empty{}, a.audit(ctx, entry, identity, nil)
+}
+
+// ListPackages returns all the packages in a universe
+func (a *api) ListPackages(ctx context.Context, req *moppi.ListPackagesRequest) (*moppi.ListPackagesResponse, error) {
+	if _, err := a.allow(ctx, req.Universe, auth.Read); err != nil {
+		return nil, err
+	}
+
+	pkgs, err := a.server.provider.GetPackages(&provider.Request{Universe: req.Universe})
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	return &moppi.ListPackagesResponse{Packages: *pkgs}, nil
+}
+
+// ListRevisions returns all the revisions of a package
+func (a *api) ListRevisions(ctx context.Context, req *moppi.ListRevisionsRequest) (*moppi.ListRevisionsResponse, error) {
+	if _, err := a.allow(ctx, req.Universe, auth.Read); err != nil {
+		return nil, err
+	}
+
+	revs, err := a.server.provider.GetRevisions(&provider.Request{Universe: req.Universe, Name: req.Name})
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	return &moppi.ListRevisionsResponse{Revisions: *revs}, nil
+}
+
+// GetPackage returns a package revision with its meta infos,
+// an empty revision or latest resolves the latest revision, that is not yanked
+func (a *api) GetPackage(ctx context.Context, req *moppi.GetPackageRequest) (*moppi.Package, error) {
+	if _, err := a.allow(ctx, req.Universe, auth.Read); err != nil {
+		return nil, err
+	}
+
+	pkgRequest := &provider.Request{Universe: req.Universe, Name: req.Name, Revision: req.Revision}
+	if pkgRequest.Revision == "" || pkgRequest.Revision == provider.RevisionLatest {
+		rev, err := a.server.provider.LatestRevision(pkgRequest)
+		if err != nil {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		pkgRequest.Revision = rev
+	}
+
+	pkg, err := a.server.provider.GetPackage(pkgRequest)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	rev, err := a.server.provider.GetRevision(pkgRequest)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	document, err := provider.Canonical(pkg)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &moppi.Package{
+		Universe: pkgRequest.Universe,
+		Name:     pkgRequest.Name,
+		Revision: pkgRequest.Revision,
+		Document: string(document),
+		Meta:     toRevision(rev),
+	}, nil
+}
+
+// PublishPackage creates a new revision of a package
+func (a *api) PublishPackage(ctx context.Context, req *moppi.PublishPackageRequest) (*moppi.PublishPackageResponse, error) {
+	pkgRequest := &provider.Request{Universe: req.Universe, Name: req.Name}
+	entry := newAuditEntry("revision.publish", "PublishPackage", pkgRequest)
+
+	identity, err := a.allow(ctx, req.Universe, auth.Publish)
+	if err != nil {
+		return nil, a.audit(ctx, entry, identity, err)
+	}
+
+	var pkg provider.Package
+	if err := json.Unmarshal([]byte(req.Document), &pkg); err != nil {
+		return nil, a.audit(ctx, entry, identity, status.Error(codes.InvalidArgument, err.Error()))
+	}
+
+	if err := a.server.validator.Struct(pkg); err != nil {
+		return nil, a.audit(ctx, entry, identity, status.Error(codes.InvalidArgument, err.Error()))
+	}
+
+	if err := a.server.verifier.Verify(req.Universe, &pkg, req.Signature); err != nil {
+		return nil, a.audit(ctx, entry, identity, status.Error(codes.PermissionDenied, err.Error()))
+	}
+
+	rev, err := a.server.provider.CreatePackageRevision(pkgRequest, &pkg, req.Signature)
+	if _, ok := err.(provider.ErrRevisionExists); ok {
+		return nil, a.audit(ctx, entry, identity, status.Error(codes.AlreadyExists, err.Error()))
+	}
+	if err != nil {
+		return nil, a.audit(ctx, entry, identity, status.Error(codes.Internal, err.Error()))
+	}
+
+	entry.Revision = strconv.Itoa(*rev)
+
+	return &moppi.PublishPackageResponse{Revision: entry.Revision}, a.audit(ctx, entry, identity, nil)
+}
+
+// YankPackage yanks a revision of a package, or all of them without a revision
+func (a *api) YankPackage(ctx context.Context, req *moppi.YankPackageRequest) (*moppi.Empty, error) {
+	pkgRequest := &provider.Request{Universe: req.Universe, Name: req.Name, Revision: req.Revision}
+
+	yank := a.server.provider.YankPackageRevision
+	entry := newAuditEntry("revision.yank", "YankPackage", pkgRequest)
+	if req.Revision == "" {
+		yank = a.server.provider.YankPackage
+		entry.Action = "package.yank"
+	}
+
+	identity, err := a.allow(ctx, req.Universe, auth.Publish)
+	if err != nil {
+		return nil, a.audit(ctx, entry, identity, err)
+	}
+
+	if err := yank(pkgRequest); err != nil {
+		return nil, a.audit(ctx, entry, identity, status.Error(codes.InvalidArgument, err.Error()))
+	}
+
+	return &moppi.Empty{}, a.audit(ctx, entry, identity, nil)
+}
+
+// Install queues the installment of a package
+func (a *api) Install(ctx context.Context, req *moppi.InstallRequest) (*moppi.Job, error) {
+	return a.enqueue(ctx, queue.KindInstall, req)
+}
+
+// Uninstall queues the uninstallment of a package
+func (a *api) Uninstall(ctx context.Context, req *moppi.InstallRequest) (*moppi.Job, error) {
+	return a.enqueue(ctx, queue.KindUninstall, req)
+}
+
+// ListJobs returns all the known jobs, the caller can read
+func (a *api) ListJobs(ctx context.Context, req *moppi.ListJobsRequest) (*moppi.ListJobsResponse, error) {
+	identity, err := a.identify(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &moppi.ListJobsResponse{}
+	for _, job := range a.server.jobs.List() {
+		if identity.Can(job.Request.Universe, auth.Read) {
+			res.Jobs = append(res.Jobs, toJob(job))
+		}
+	}
+
+	return res, nil
+}
+
+// GetJob returns a job
+func (a *api) GetJob(ctx context.Context, req *moppi.GetJobRequest) (*moppi.Job, error) {
+	identity, err := a.identify(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	job, ok := a.server.jobs.Get(req.Id)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "Unknown job %v", req.Id)
+	}
+
+	if err := authorize(identity, job.Request.Universe, auth.Read); err != nil {
+		return nil, err
+	}
+
+	return toJob(job), nil
+}
+
+// enqueue queues an installment or uninstallment of a package
+func (a *api) enqueue(ctx context.Context, kind string, req *moppi.InstallRequest) (*moppi.Job, error) {
+	pkgRequest := &provider.Request{Universe: req.Universe, Name: req.Name, Revision: req.Revision}
+	entry := newAuditEntry(kind, strings.Title(kind), pkgRequest)
+
+	identity, err := a.allow(ctx, req.Universe, auth.Install)
+	if err != nil {
+		return nil, a.audit(ctx, entry, identity, err)
+	}
+
+	job, err := a.server.enqueue(kind, pkgRequest, identity, a.server.sourceIP(ctx), callID(ctx))
+	entry.Revision = pkgRequest.Revision
+	if err != nil {
+		return nil, a.audit(ctx, entry, identity, status.Error(enqueueCode(err), err.Error()))
+	}
+	entry.Job = job.ID
+
+	return toJob(job), a.audit(ctx, entry, identity, nil)
+}
+
+// identify authenticates the caller with the authorization of the metadata
+func (a *api) identify(ctx context.Context) (*auth.Identity, error) {
+	req := &http.Request{Header: make(http.Header)}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for _, authorization := range md[authorizationKey] {
+			req.Header.Add("Authorization", authorization)
+		}
+	}
+
+	identity, err := a.server.currentAuth().Authenticate(req)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	return identity, nil
+}
+
+// allow authenticates the caller and checks if it has a role in a universe
+func (a *api) allow(ctx context.Context, universe string, role auth.Role) (*auth.Identity, error) {
+	identity, err := a.identify(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return identity, authorize(identity, universe, role)
+}
+
+// audit records a mutating call in the audit log, and passes along its error
+func (a *api) audit(ctx context.Context, entry *provider.AuditEntry, identity *auth.Identity, err error) error {
+	if identity != nil {
+		entry.Identity = identity.Name
+	}
+	entry.SourceIP = a.server.sourceIP(ctx)
+	entry.Status = http.StatusOK
+	entry.Result = audit.ResultSuccess
+
+	if err != nil {
+		s, _ := status.FromError(err)
+		entry.Status = runtime.HTTPStatusFromCode(s.Code())
+		entry.Result = audit.ResultFailure
+		entry.Error = s.Message()
+	}
+
+	a.server.auditLog.Record(entry)
+	a.server.publishEntry(entry)
+
+	return err
+}
+
+// authorize checks if an identity has a role in a universe
+func authorize(identity *auth.Identity, universe string, role auth.Role) error {
+	if err := identity.Authorize(universe, role); err != nil {
+		if identity.Anonymous {
+			return status.Error(codes.Unauthenticated, err.Error())
+		}
+
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	return nil
+}
+
+// enqueueCode maps an error of enqueue to a status code
+func enqueueCode(err error) codes.Code {
+	switch err.(type) {
+	case provider.ErrRevisionYanked:
+		return codes.FailedPrecondition
+	case signing.ErrUnsigned, signing.ErrBadSignature, signing.ErrMalformed:
+		return codes.PermissionDenied
+	case queue.ErrFull:
+		return codes.ResourceExhausted
+	}
+
+	return codes.InvalidArgument
+}
+
+// newAuditEntry returns the audit entry of a call of the api
+func newAuditEntry(action string, method string, req *provider.Request) *provider.AuditEntry {
+	return &provider.AuditEntry{
+		Action:   action,
+		Universe: req.Universe,
+		Package:  req.Name,
+		Revision: req.Revision,
+		Summary:  "gRPC " + method,
+	}
+}
+
+// sourceIP returns the address of the caller. The in-process gateway calls
+// without a peer, and adds the remote address of its request to the forwarded
+// addresses. The forwarded addresses of other callers are only honoured from
+// a trusted proxy
+func (server *Server) sourceIP(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	trusted := server.currentProxies()
+
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return trusted.client("", md[forwardedKey])
+	}
+
+	addr := host(p.Addr.String())
+	if !trusted.trusts(addr) {
+		return addr
+	}
+
+	return trusted.client(addr, md[forwardedKey])
+}
+
+// toUniverse converts a universe to its message
+func toUniverse(id string, universe *provider.Universe) *moppi.Universe {
+	return &moppi.Universe{
+		Id:          id,
+		Name:        universe.Name,
+		Description: universe.Description,
+		Version:     universe.Version,
+		Href:        universe.Href,
+	}
+}
+
+// toRevision converts the meta infos of a revision to its message
+func toRevision(rev *provider.Revision) *moppi.Revision {
+	return &moppi.Revision{
+		Digest:    rev.Digest,
+		Created:   rev.Created,
+		Yanked:    rev.Yanked,
+		YankedAt:  rev.YankedAt,
+		Signature: rev.Signature,
+	}
+}
+
+// toJob converts a job to its message
+func toJob(job queue.Job) *moppi.Job {
+	return &moppi.Job{
+		Id:   job.ID,
+		Kind: job.Kind,
+		Request: &moppi.InstallRequest{
+			Universe: job.Request.Universe,
+			Name:     job.Request.Name,
+			Revision: job.Request.Revision,
+		},
+		Identity: job.Identity,
+		SourceIp: job.SourceIP,
+		State:    job.State,
+		Error:    job.Error,
+		Created:  job.Created.Format(time.RFC3339),
+		Started:  formatTime(job.Started),
+		Finished: formatTime(job.Finished),
+	}
+}
+
+// formatTime formats an optional time
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,169 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/axelspringer/moppi/audit"
+	"github.com/axelspringer/moppi/auth"
+	"github.com/axelspringer/moppi/provider"
+	"github.com/axelspringer/moppi/queue"
+	"github.com/zenazn/goji/web"
+	"github.com/zenazn/goji/web/mutil"
+)
+
+// record is a middleware, that records every mutating call in the audit log
+func (server *Server) record(c *web.C, h http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, req *http.Request) {
+		// the gRPC api records its own calls
+		if strings.HasPrefix(req.URL.Path, gatewayPrefix) {
+			h.ServeHTTP(w, req)
+			return
+		}
+
+		switch req.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			h.ServeHTTP(w, req)
+			return
+		}
+
+		if c.Env == nil {
+			c.Env = make(map[interface{}]interface{})
+		}
+
+		// the handlers annotate the entry with the details of the call
+		entry := &provider.AuditEntry{Action: strings.ToLower(req.Method)}
+		c.Env[auditKey] = entry
+
+		ww := mutil.WrapWriter(w)
+
+		// a panic is recorded as a 500, before it is recovered
+		defer func() {
+			entry.Status = responseStatus(ww)
+			if err := recover(); err != nil {
+				entry.Status = http.StatusInternalServerError
+				defer panic(err)
+			}
+
+			entry.Identity = identity(*c).Name
+			entry.SourceIP = server.remoteIP(req)
+			entry.Summary = req.Method + " " + req.URL.Path
+			entry.Result = audit.ResultSuccess
+			if entry.Status >= http.StatusBadRequest {
+				entry.Result = audit.ResultFailure
+			}
+
+			server.auditLog.Record(entry)
+			server.publishEntry(entry)
+		}()
+
+		h.ServeHTTP(ww, req)
+	}
+
+	return http.HandlerFunc(fn)
+}
+
+// annotate adds the details of a call to its audit entry
+func annotate(c web.C, action string, req *provider.Request) *provider.AuditEntry {
+	entry, ok := c.Env[auditKey].(*provider.AuditEntry)
+	if !ok {
+		entry = &provider.AuditEntry{} // not audited
+	}
+
+	entry.Action = action
+	if req != nil {
+		entry.Universe = req.Universe
+		entry.Package = req.Name
+		entry.Revision = req.Revision
+	}
+
+	return entry
+}
+
+// auditJob records the outcome of a job in the audit log
+func (server *Server) auditJob(job queue.Job) {
+	if job.Finished == nil {
+		return
+	}
+
+	entry := &provider.AuditEntry{
+		Time:     *job.Finished,
+		Identity: job.Identity,
+		SourceIP: job.SourceIP,
+		Action:   "job." + job.Kind,
+		Universe: job.Request.Universe,
+		Package:  job.Request.Name,
+		Revision: job.Request.Revision,
+		Job:      job.ID,
+		Summary:  job.Kind + " " + job.Request.Universe + "/" + job.Request.Name + "@" + job.Request.Revision,
+		Result:   audit.ResultSuccess,
+	}
+
+	if job.State == queue.StateFailed || job.State == queue.StateInterrupted || job.State == queue.StateCanceled {
+		entry.Result = audit.ResultFailure
+		entry.Error = job.Error
+	}
+
+	server.auditLog.Record(entry)
+}
+
+// getAudit returns the entries of the audit log, newest first
+func (server *Server) getAudit(c web.C, w http.ResponseWriter, req *http.Request) {
+	if !server.authorize(c, w, auth.AnyUniverse, auth.Admin) {
+		return
+	}
+
+	query := req.URL.Query()
+	filter := &audit.Filter{
+		Universe: query.Get("universe"),
+		Identity: query.Get("identity"),
+		Action:   query.Get("action"),
+		Result:   query.Get("result"),
+	}
+
+	var err error
+	if since := query.Get("since"); since != "" {
+		if filter.Since, err = time.Parse(time.RFC3339, since); err != nil {
+			writeErrorJSON(w, "Could not parse since", http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	if until := query.Get("until"); until != "" {
+		if filter.Until, err = time.Parse(time.RFC3339, until); err != nil {
+			writeErrorJSON(w, "Could not parse until", http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		if filter.Limit, err = strconv.Atoi(limit); err != nil {
+			writeErrorJSON(w, "Could not parse limit", http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	entries, err := server.auditLog.Query(filter)
+	if err != nil {
+		writeErrorJSON(w, "Could not retrieve the audit log", http.StatusBadGateway, err)
+		return
+	}
+
+	writeJSON(w, entries)
+}
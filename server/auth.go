@@ -0,0 +1,84 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"net/http"
+
+	"github.com/axelspringer/moppi/auth"
+	"github.com/zenazn/goji/web"
+)
+
+// authenticate is a middleware, that authenticates every request
+// and stores the identity of the caller in the environment
+func (server *Server) authenticate(c *web.C, h http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, req *http.Request) {
+		identity, err := server.currentAuth().Authenticate(req)
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", authChallenge)
+			writeErrorJSON(w, "Could not authenticate the request", http.StatusUnauthorized, err)
+			return
+		}
+
+		if c.Env == nil {
+			c.Env = make(map[interface{}]interface{})
+		}
+		c.Env[identityKey] = identity
+
+		h.ServeHTTP(w, req)
+	}
+
+	return http.HandlerFunc(fn)
+}
+
+// identity returns the identity of the caller
+func identity(c web.C) *auth.Identity {
+	if identity, ok := c.Env[identityKey].(*auth.Identity); ok {
+		return identity
+	}
+
+	return &auth.Identity{} // has no roles
+}
+
+// authorize checks if the caller has a role in a universe,
+// otherwise it writes the error and returns false
+func (server *Server) authorize(c web.C, w http.ResponseWriter, universe string, role auth.Role) bool {
+	identity := identity(c)
+
+	if err := identity.Authorize(universe, role); err != nil {
+		if identity.Anonymous {
+			w.Header().Set("WWW-Authenticate", authChallenge)
+			writeErrorJSON(w, "Please authenticate", http.StatusUnauthorized, err)
+			return false
+		}
+
+		writeErrorJSON(w, "Not allowed", http.StatusForbidden, err)
+		return false
+	}
+
+	return true
+}
+
+// restrict wraps a handler of the universes router, and only passes
+// callers that have the role in the requested universe
+func (server *Server) restrict(role auth.Role, h web.HandlerFunc) web.HandlerFunc {
+	return func(c web.C, w http.ResponseWriter, req *http.Request) {
+		if !server.authorize(c, w, c.URLParams["universe"], role) {
+			return
+		}
+
+		h(c, w, req)
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/axelspringer/moppi/auth"
+	"github.com/axelspringer/moppi/cfg"
+	"github.com/axelspringer/moppi/server"
+)
+
+var _ = Describe("Auth", func() {
+	var (
+		mux   http.Handler
+		serve func(path string, header string) (*httptest.ResponseRecorder, *server.Error)
+	)
+
+	BeforeEach(func() {
+		a, err := auth.New(&cfg.Config{Auth: cfg.Auth{
+			Enable: true,
+			Tokens: []cfg.Token{
+				{Name: "ci", Token: "publisher", Roles: cfg.Roles{"prod": {"publish"}}},
+				{Name: "ops", Token: "admin", Roles: cfg.Roles{"*": {"admin"}}},
+			},
+			Anonymous: cfg.Roles{"dev": {"read"}},
+		}})
+		Expect(err).NotTo(HaveOccurred())
+
+		mux = server.NewAuthMux(a)
+
+		serve = func(path string, header string) (*httptest.ResponseRecorder, *server.Error) {
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			if header != "" {
+				req.Header.Set("Authorization", header)
+			}
+
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			if w.Code == http.StatusOK {
+				return w, nil
+			}
+
+			var body server.Error
+			Expect(json.Unmarshal(w.Body.Bytes(), &body)).To(Succeed())
+			return w, &body
+		}
+	})
+
+	It("passes callers with the role in the universe", func() {
+		w, _ := serve("/universes/prod/publish", "Bearer publisher")
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Body.String()).To(Equal(`"ci"`))
+
+		w, _ = serve("/universes/prod/read", "Bearer publisher")
+		Expect(w.Code).To(Equal(http.StatusOK))
+
+		w, _ = serve("/universes/dev/admin", "Bearer admin")
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Body.String()).To(Equal(`"ops"`))
+	})
+
+	It("passes anonymous callers with the anonymous roles", func() {
+		w, _ := serve("/universes/dev/read", "")
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Body.String()).To(Equal(`"anonymous"`))
+	})
+
+	It("answers invalid credentials with a 401 and a challenge", func() {
+		w, body := serve("/universes/dev/read", "Bearer unknown")
+		Expect(w.Code).To(Equal(http.StatusUnauthorized))
+		Expect(w.Header().Get("WWW-Authenticate")).To(Equal(`Bearer realm="moppi", Basic realm="moppi"`))
+		Expect(w.Header().Get("Content-Type")).To(Equal("application/json"))
+		Expect(body).To(Equal(&server.Error{
+			Msg: "Could not authenticate the request",
+			Err: "Invalid credentials: unsupported authorization scheme",
+		}))
+	})
+
+	It("asks anonymous callers without the role to authenticate", func() {
+		w, body := serve("/universes/prod/read", "")
+		Expect(w.Code).To(Equal(http.StatusUnauthorized))
+		Expect(w.Header().Get("WWW-Authenticate")).NotTo(BeEmpty())
+		Expect(body).To(Equal(&server.Error{
+			Msg: "Please authenticate",
+			Err: "anonymous is not allowed to read in universe prod",
+		}))
+	})
+
+	It("forbids authenticated callers without the role", func() {
+		w, body := serve("/universes/prod/install", "Bearer publisher")
+		Expect(w.Code).To(Equal(http.StatusForbidden))
+		Expect(w.Header().Get("WWW-Authenticate")).To(BeEmpty())
+		Expect(body).To(Equal(&server.Error{
+			Msg: "Not allowed",
+			Err: "ci is not allowed to install in universe prod",
+		}))
+
+		w, _ = serve("/universes/dev/publish", "Bearer publisher")
+		Expect(w.Code).To(Equal(http.StatusForbidden))
+	})
+})
@@ -14,6 +14,40 @@
 
 package server
 
+import "time"
+
+const (
+	okString         = "OK"
+	signatureHeader  = "X-Moppi-Signature"
+	identityKey      = "identity"
+	auditKey         = "audit"
+	authChallenge    = `Bearer realm="moppi", Basic realm="moppi"`
+	gatewayPrefix    = "/v1/"
+	eventsHeartbeat  = 15 * time.Second
+	authorizationKey = "authorization"
+	forwardedKey     = "x-forwarded-for"
+	routeKey         = "route"
+	routeUnmatched   = "unmatched"
+	requestIDKey     = "request_id"
+	requestIDHeader  = "X-Request-ID"
+	requestIDMeta    = "x-request-id"
+	healthTimeout    = 5 * time.Second
+	readyRetry       = 5 * time.Second
+	defaultWorkers   = 1
+	retryAfter       = "30"
+)
+
+const (
+	healthOK       = "ok"
+	healthDegraded = "degraded"
+	healthFailed   = "failed"
+)
+
 const (
-	okString = "OK"
+	componentKV        = "kv"
+	componentMarathon  = "marathon"
+	componentChronos   = "chronos"
+	componentMetronome = "metronome"
+	componentMesos     = "mesos"
+	componentQueue     = "queue"
 )
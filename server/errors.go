@@ -14,7 +14,10 @@
 
 package server
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // PackageRequestFieldMissing is a new type that inherits error
 type PackageRequestFieldMissing string
@@ -23,3 +26,51 @@ type PackageRequestFieldMissing string
 func (err PackageRequestFieldMissing) Error() string {
 	return fmt.Sprintf("A field is missing: %v", string(err))
 }
+
+// ErrStreaming is returned when the events could not be streamed
+type ErrStreaming string
+
+// Error returns a custom error
+func (err ErrStreaming) Error() string {
+	return fmt.Sprintf("Streaming is not supported: %v", string(err))
+}
+
+// ErrNotReady is returned when the server is not ready yet
+type ErrNotReady string
+
+// Error returns a custom error
+func (err ErrNotReady) Error() string {
+	return fmt.Sprintf("The server is not ready: %v", string(err))
+}
+
+// ErrTimeout is returned when a component did not answer in time
+type ErrTimeout time.Duration
+
+// Error returns a custom error
+func (err ErrTimeout) Error() string {
+	return fmt.Sprintf("No answer within %v", time.Duration(err))
+}
+
+// ErrNoLeader is returned when a scheduler has no elected leader
+type ErrNoLeader string
+
+// Error returns a custom error
+func (err ErrNoLeader) Error() string {
+	return fmt.Sprintf("%v has no elected leader", string(err))
+}
+
+// ErrSaturated is returned when the queue is saturated with jobs
+type ErrSaturated int
+
+// Error returns a custom error
+func (err ErrSaturated) Error() string {
+	return fmt.Sprintf("The queue is saturated with %d jobs", int(err))
+}
+
+// ErrProxy is returned when a trusted proxy is neither an ip nor a CIDR
+type ErrProxy string
+
+// Error returns a custom error
+func (err ErrProxy) Error() string {
+	return fmt.Sprintf("The trusted proxy is neither an ip nor a CIDR: %v", string(err))
+}
@@ -0,0 +1,204 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/axelspringer/moppi/audit"
+	"github.com/axelspringer/moppi/auth"
+	"github.com/axelspringer/moppi/events"
+	"github.com/axelspringer/moppi/provider"
+	"github.com/axelspringer/moppi/queue"
+	"github.com/zenazn/goji/web"
+	"golang.org/x/net/websocket"
+)
+
+// publishJob publishes the state transitions of a job
+func (server *Server) publishJob(job queue.Job) {
+	server.events.Publish(events.Event{
+		Type:     events.TypeJob,
+		Universe: job.Request.Universe,
+		Package:  job.Request.Name,
+		Revision: job.Request.Revision,
+		Job:      job.ID,
+		Kind:     job.Kind,
+		State:    job.State,
+		Identity: job.Identity,
+		Error:    job.Error,
+	})
+}
+
+// publishEntry publishes a successful mutation of a universe or package,
+// which is recorded in the audit log (e.g. universe.create or revision.yank)
+func (server *Server) publishEntry(entry *provider.AuditEntry) {
+	if entry.Result != audit.ResultSuccess {
+		return
+	}
+
+	parts := strings.SplitN(entry.Action, ".", 2)
+	if len(parts) != 2 {
+		return
+	}
+
+	switch parts[0] {
+	case events.TypeUniverse, events.TypePackage, events.TypeRevision:
+	default:
+		return // jobs publish their own events
+	}
+
+	server.events.Publish(events.Event{
+		Type:     parts[0],
+		Universe: entry.Universe,
+		Package:  entry.Package,
+		Revision: entry.Revision,
+		State:    parts[1],
+		Identity: entry.Identity,
+		Source:   events.SourceAPI,
+	})
+}
+
+// watch publishes the changes of the universes in the KV, also
+// those made directly in the KV, until the server exits
+func (server *Server) watch() {
+	for change := range server.provider.Watch(server.stopped()) {
+		parts := strings.SplitN(change.Kind, ".", 2)
+
+		server.events.Publish(events.Event{
+			Type:     parts[0],
+			Universe: change.Universe,
+			Package:  change.Package,
+			Revision: change.Revision,
+			State:    parts[1],
+			Source:   events.SourceKV,
+		})
+	}
+}
+
+// getEvents streams the events as Server-Sent Events, a reconnecting
+// client resumes with the Last-Event-ID header
+func (server *Server) getEvents(c web.C, w http.ResponseWriter, req *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeErrorJSON(w, "Could not stream the events", http.StatusInternalServerError, ErrStreaming("flush"))
+		return
+	}
+
+	after, _ := strconv.ParseUint(req.Header.Get("Last-Event-ID"), 10, 64)
+	sub := server.events.Subscribe(eventsFilter(req), after)
+	defer sub.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	identity := identity(c)
+	heartbeat := time.NewTicker(eventsHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case event, ok := <-sub.C:
+			if !ok {
+				return
+			}
+
+			if !identity.Can(event.Universe, auth.Read) {
+				continue
+			}
+
+			data, err := json.Marshal(event)
+			if err != nil {
+				continue
+			}
+
+			fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+			flusher.Flush()
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": ping\n\n")
+			flusher.Flush()
+		case <-req.Context().Done():
+			return
+		case <-server.exit:
+			return
+		}
+	}
+}
+
+// getEventsWS streams the events as JSON messages over a WebSocket
+func (server *Server) getEventsWS(c web.C, w http.ResponseWriter, req *http.Request) {
+	identity := identity(c)
+	after, _ := strconv.ParseUint(req.URL.Query().Get("after"), 10, 64)
+
+	ws := websocket.Server{Handler: func(conn *websocket.Conn) {
+		sub := server.events.Subscribe(eventsFilter(req), after)
+		defer sub.Close()
+
+		// the client does not send anything, a read fails when it is gone
+		gone := make(chan struct{})
+		go func() {
+			var msg []byte
+			for websocket.Message.Receive(conn, &msg) == nil {
+			}
+			close(gone)
+		}()
+
+		for {
+			select {
+			case event, ok := <-sub.C:
+				if !ok {
+					return
+				}
+
+				if !identity.Can(event.Universe, auth.Read) {
+					continue
+				}
+
+				if err := websocket.JSON.Send(conn, event); err != nil {
+					return
+				}
+			case <-gone:
+				return
+			case <-server.exit:
+				return
+			}
+		}
+	}}
+
+	ws.ServeHTTP(w, req)
+}
+
+// eventsFilter returns the filter of the events of a request
+func eventsFilter(req *http.Request) events.Filter {
+	query := req.URL.Query()
+
+	filter := events.Filter{
+		Universe: query.Get("universe"),
+		Job:      query.Get("job"),
+	}
+
+	if types := query.Get("type"); types != "" {
+		filter.Types = strings.Split(types, ",")
+	}
+
+	return filter
+}
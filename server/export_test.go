@@ -0,0 +1,39 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"net/http"
+
+	"github.com/axelspringer/moppi/auth"
+	"github.com/zenazn/goji/web"
+)
+
+// NewAuthMux returns a mux, that authenticates every request like the server,
+// and restricts /universes/:universe/<role> to callers with the role
+func NewAuthMux(a *auth.Auth) http.Handler {
+	server := &Server{auth: a}
+
+	mux := web.New()
+	mux.Use(server.authenticate)
+
+	for _, role := range []auth.Role{auth.Read, auth.Publish, auth.Install, auth.Admin} {
+		mux.Get("/universes/:universe/"+string(role), server.restrict(role, func(c web.C, w http.ResponseWriter, req *http.Request) {
+			writeJSON(w, identity(c).Name)
+		}))
+	}
+
+	return mux
+}
@@ -0,0 +1,251 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/axelspringer/moppi/cfg"
+	"github.com/axelspringer/moppi/provider"
+	"github.com/axelspringer/moppi/queue"
+	"github.com/axelspringer/moppi/version"
+	"github.com/zenazn/goji/web"
+)
+
+// health reports the health of the server and of the components it depends on,
+// it fails when the KV is unreachable. A saturated queue only degrades it
+func (server *Server) health(c web.C, w http.ResponseWriter, _ *http.Request) {
+	health := server.checkHealth()
+
+	status := http.StatusOK
+	if health.Status == healthFailed {
+		status = http.StatusServiceUnavailable
+	}
+
+	writeJSONStatus(w, status, health)
+}
+
+// ready reports if the server is ready, the repo in the KV has to be set up
+// and has to match the version of moppi, and the queue must not be saturated
+func (server *Server) ready(c web.C, w http.ResponseWriter, _ *http.Request) {
+	if err := server.readiness.get(); err != nil {
+		writeErrorJSON(w, "Not ready", http.StatusServiceUnavailable, err)
+		return
+	}
+
+	if _, err := server.checkQueue(); err != nil {
+		writeErrorJSON(w, "Not ready", http.StatusServiceUnavailable, err)
+		return
+	}
+
+	io.WriteString(w, okString)
+}
+
+// prepare checks the repo in the KV, until the server is ready
+func (server *Server) prepare(stop <-chan struct{}) {
+	for {
+		err := server.checkRepo()
+		server.readiness.set(err)
+		if err == nil {
+			cfg.Log.Info("Server is ready")
+			return
+		}
+
+		cfg.Log.WithError(err).Warn("Server is not ready")
+		select {
+		case <-stop:
+			return
+		case <-time.After(readyRetry):
+		}
+	}
+}
+
+// checkRepo checks that the repo in the KV is set up and matches the version
+func (server *Server) checkRepo() error {
+	ok, err := server.provider.IsSetup()
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return provider.ErrNotSetup(server.provider.Prefix)
+	}
+
+	_, err = server.provider.CheckVersion(version.Version)
+	return err
+}
+
+// checkHealth checks the components in parallel, a component
+// that does not answer in time is failed
+func (server *Server) checkHealth() *Health {
+	checks := map[string]func() (string, error){
+		componentKV:       server.checkKV,
+		componentMarathon: server.checkMarathon,
+		componentChronos:  server.checkChronos,
+		componentMesos:    server.checkMesos,
+		componentQueue:    server.checkQueue,
+	}
+	if server.currentInstaller().Metronome != nil {
+		checks[componentMetronome] = server.checkMetronome
+	}
+
+	type result struct {
+		name      string
+		component *ComponentHealth
+	}
+
+	results := make(chan result, len(checks))
+	for name, check := range checks {
+		go func(name string, check func() (string, error)) {
+			start := time.Now()
+			detail, err := check()
+
+			component := &ComponentHealth{
+				Status:  healthOK,
+				Latency: float64(time.Since(start)) / float64(time.Millisecond),
+				Detail:  detail,
+			}
+			if err != nil {
+				component.Status = healthFailed
+				component.Error = err.Error()
+			}
+
+			results <- result{name, component}
+		}(name, check)
+	}
+
+	health := &Health{
+		Status:     healthOK,
+		Components: make(map[string]*ComponentHealth, len(checks)),
+	}
+
+	timeout := time.After(healthTimeout)
+	for len(health.Components) < len(checks) {
+		select {
+		case r := <-results:
+			health.Components[r.name] = r.component
+			continue
+		case <-timeout:
+		}
+
+		for name := range checks {
+			if _, ok := health.Components[name]; !ok {
+				health.Components[name] = &ComponentHealth{
+					Status:  healthFailed,
+					Latency: float64(healthTimeout) / float64(time.Millisecond),
+					Error:   ErrTimeout(healthTimeout).Error(),
+				}
+			}
+		}
+	}
+
+	// the KV is required to serve, the schedulers are only required
+	// for installments, and a saturated queue drains by itself
+	for name, component := range health.Components {
+		if component.Status == healthOK {
+			continue
+		}
+
+		switch name {
+		case componentKV:
+			health.Status = healthFailed
+		default:
+			if health.Status == healthOK {
+				health.Status = healthDegraded
+			}
+		}
+	}
+
+	return health
+}
+
+// checkKV checks a round trip to the KV
+func (server *Server) checkKV() (string, error) {
+	ver, err := server.provider.Version()
+	if err != nil {
+		return "", err
+	}
+
+	return "version " + string(ver.Value), nil
+}
+
+// checkMarathon checks that Marathon is reachable
+func (server *Server) checkMarathon() (string, error) {
+	_, err := server.currentInstaller().Marathon.Ping()
+	return "", err
+}
+
+// checkMetronome checks that Metronome is reachable
+func (server *Server) checkMetronome() (string, error) {
+	return "", server.currentInstaller().Metronome.Ping()
+}
+
+// checkChronos checks that Chronos is reachable
+func (server *Server) checkChronos() (string, error) {
+	return "", server.currentInstaller().PingChronos()
+}
+
+// checkMesos checks that the Mesos master is reachable, and has an elected leader
+func (server *Server) checkMesos() (string, error) {
+	state, err := server.currentInstaller().Mesos.State()
+	if err != nil {
+		return "", err
+	}
+
+	if state.Leader == "" {
+		return "", ErrNoLeader("Mesos")
+	}
+
+	return "leader " + state.Leader, nil
+}
+
+// checkQueue checks that the queue is not saturated
+func (server *Server) checkQueue() (string, error) {
+	var queued, running int
+	for _, job := range server.jobs.List() {
+		switch job.State {
+		case queue.StateQueued:
+			queued++
+		case queue.StateRunning:
+			running++
+		}
+	}
+
+	detail := fmt.Sprintf("%d queued, %d running", queued, running)
+	if queued >= server.queue.Capacity() {
+		return detail, ErrSaturated(queued)
+	}
+
+	return detail, nil
+}
+
+// get returns the reason, why the server is not ready
+func (r *readiness) get() error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.err
+}
+
+// set sets the reason, why the server is not ready, nil if it is ready
+func (r *readiness) set(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.err = err
+}
@@ -15,6 +15,8 @@
 package server
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -32,6 +34,13 @@ func writeJSON(w http.ResponseWriter, data interface{}) {
 	io.WriteString(w, string(json))
 }
 
+// writeJSONStatus emits a status and writes the JSON
+func writeJSONStatus(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	writeJSON(w, data)
+}
+
 // writeError emits an error with a message and the error
 func writeError(w http.ResponseWriter, msg string, status int, err error) {
 	w.WriteHeader(status)
@@ -41,8 +50,7 @@ func writeError(w http.ResponseWriter, msg string, status int, err error) {
 
 // writeJSONError emits the error a JSON
 func writeErrorJSON(w http.ResponseWriter, msg string, status int, err error) {
-	w.WriteHeader(status)
-	writeJSON(w, &Error{msg, err.Error()})
+	writeJSONStatus(w, status, &Error{Msg: msg, Err: err.Error()})
 }
 
 // readRequest reads in a request
@@ -84,6 +92,25 @@ func parseRequest(r io.Reader) (*provider.Request, error) {
 // 	return req, err
 // }
 
+// stopped returns a channel, that is closed when the server exits
+func (server *Server) stopped() <-chan struct{} {
+	stop := make(chan struct{})
+	go func() {
+		<-server.exit
+		close(stop)
+	}()
+
+	return stop
+}
+
+// newID returns a random id
+func newID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
+
 // getHostname returns the hostname
 func getHostname() (hostname string, err error) {
 	host, err := os.Hostname()
@@ -0,0 +1,91 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"net/http"
+
+	"github.com/axelspringer/moppi/auth"
+	"github.com/axelspringer/moppi/provider"
+	"github.com/axelspringer/moppi/queue"
+	"github.com/axelspringer/moppi/signing"
+)
+
+// enqueue verifies the requested package revision and queues an installment
+// or uninstallment of it, the revision of the request is resolved
+func (server *Server) enqueue(kind string, req *provider.Request, identity *auth.Identity, sourceIP string, requestID string) (queue.Job, error) {
+	pkg, rev, err := server.provider.GetVerifiedPackage(req)
+	if err != nil {
+		return queue.Job{}, err
+	}
+
+	if kind == queue.KindInstall {
+		if rev.Yanked {
+			return queue.Job{}, provider.ErrRevisionYanked(req.Revision)
+		}
+
+		if err := server.verifier.Verify(req.Universe, pkg, rev.Signature); err != nil {
+			return queue.Job{}, err
+		}
+	}
+
+	job := &queue.Job{
+		Kind:      kind,
+		Request:   *req,
+		Identity:  identity.Name,
+		SourceIP:  sourceIP,
+		RequestID: requestID,
+	}
+
+	installer := server.currentInstaller()
+	var work interface{} = &queue.Install{
+		Universe:  req.Universe,
+		Package:   pkg,
+		Revision:  rev,
+		Installer: installer,
+		Verifier:  server.verifier,
+		Events:    server.events,
+		Job:       job,
+	}
+	if kind == queue.KindUninstall {
+		work = &queue.Uninstall{Package: pkg, Installer: installer, Job: job}
+	}
+
+	return server.queue.Enqueue(work)
+}
+
+// enqueueStatus maps an error of enqueue to a status code
+func enqueueStatus(err error) int {
+	switch err.(type) {
+	case provider.ErrRevisionYanked:
+		return http.StatusGone
+	case signing.ErrUnsigned, signing.ErrBadSignature, signing.ErrMalformed:
+		return http.StatusForbidden
+	case queue.ErrFull:
+		return http.StatusServiceUnavailable
+	}
+
+	return http.StatusBadRequest
+}
+
+// writeEnqueueError writes an error of enqueue, the client is asked
+// to retry later, when the queue is full
+func writeEnqueueError(w http.ResponseWriter, message string, err error) {
+	if _, ok := err.(queue.ErrFull); ok {
+		w.Header().Set("Retry-After", retryAfter)
+	}
+
+	writeErrorJSON(w, message, enqueueStatus(err), err)
+}
@@ -0,0 +1,79 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/axelspringer/moppi/auth"
+	"github.com/axelspringer/moppi/queue"
+	"github.com/zenazn/goji/web"
+)
+
+// getJobs returns all the known jobs, the caller can read
+func (server *Server) getJobs(c web.C, w http.ResponseWriter, _ *http.Request) {
+	identity := identity(c)
+
+	jobs := make([]queue.Job, 0)
+	for _, job := range server.jobs.List() {
+		if identity.Can(job.Request.Universe, auth.Read) {
+			jobs = append(jobs, job)
+		}
+	}
+
+	writeJSON(w, jobs)
+}
+
+// getJob returns a job
+func (server *Server) getJob(c web.C, w http.ResponseWriter, _ *http.Request) {
+	job, ok := server.jobs.Get(c.URLParams["id"])
+	if !ok {
+		writeErrorJSON(w, "Could not retrieve the job", http.StatusNotFound, errors.New("Unknown job"))
+		return
+	}
+
+	if !server.authorize(c, w, job.Request.Universe, auth.Read) {
+		return
+	}
+
+	writeJSON(w, job)
+}
+
+// cancelJob cancels a queued or running job, what a running job
+// created is deleted with the rollback parameter
+func (server *Server) cancelJob(c web.C, w http.ResponseWriter, req *http.Request) {
+	job, ok := server.jobs.Get(c.URLParams["id"])
+	if !ok {
+		writeErrorJSON(w, "Could not cancel the job", http.StatusNotFound, errors.New("Unknown job"))
+		return
+	}
+
+	entry := annotate(c, "job.cancel", &job.Request)
+	entry.Job = job.ID
+	if !server.authorize(c, w, job.Request.Universe, auth.Install) {
+		return
+	}
+
+	rollback, _ := strconv.ParseBool(req.URL.Query().Get("rollback"))
+	job, err := server.queue.Cancel(job.ID, rollback)
+	if err != nil {
+		writeErrorJSON(w, "Could not cancel the job", http.StatusConflict, err)
+		return
+	}
+
+	writeJSONStatus(w, http.StatusAccepted, job)
+}
@@ -0,0 +1,103 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/axelspringer/moppi/cfg"
+	log "github.com/sirupsen/logrus"
+	"github.com/zenazn/goji/web"
+	"github.com/zenazn/goji/web/mutil"
+	"google.golang.org/grpc/metadata"
+)
+
+// requestID is a middleware, that takes the id of a request from its
+// X-Request-ID header or adds one, the id is answered along
+func (server *Server) requestID(c *web.C, h http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, req *http.Request) {
+		id := req.Header.Get(requestIDHeader)
+		if id == "" {
+			id = newID()
+			req.Header.Set(requestIDHeader, id) // passed along to the gateway
+		}
+
+		if c.Env == nil {
+			c.Env = make(map[interface{}]interface{})
+		}
+		c.Env[requestIDKey] = id
+
+		w.Header().Set(requestIDHeader, id)
+		h.ServeHTTP(w, req)
+	}
+
+	return http.HandlerFunc(fn)
+}
+
+// access is a middleware, that logs a line for every request
+func (server *Server) access(c *web.C, h http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, req *http.Request) {
+		start := time.Now()
+
+		ww := mutil.WrapWriter(w)
+		h.ServeHTTP(ww, req)
+
+		fields := log.Fields{
+			"request_id": requestID(*c),
+			"method":     req.Method,
+			"path":       req.URL.Path,
+			"status":     responseStatus(ww),
+			"duration":   time.Since(start).Seconds(),
+			"identity":   identity(*c).Name,
+			"remote_ip":  server.remoteIP(req),
+		}
+		if route, ok := c.Env[routeKey].(*string); ok {
+			fields["route"] = *route
+		}
+
+		cfg.Log.WithFields(fields).Info("Request")
+	}
+
+	return http.HandlerFunc(fn)
+}
+
+// requestID returns the id of a request
+func requestID(c web.C) string {
+	id, _ := c.Env[requestIDKey].(string)
+	return id
+}
+
+// callID returns the id of a call of the gRPC api from its
+// metadata, the gateway passes along the id of its request
+func callID(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if id := md[requestIDMeta]; len(id) > 0 && id[0] != "" {
+			return id[0]
+		}
+	}
+
+	return newID()
+}
+
+// responseStatus returns the status of a response, it is 200 when nothing was written
+func responseStatus(w mutil.WriterProxy) int {
+	if w.Status() == 0 {
+		return http.StatusOK
+	}
+
+	return w.Status()
+}
@@ -0,0 +1,88 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/axelspringer/moppi/metrics"
+	"github.com/axelspringer/moppi/queue"
+	"github.com/zenazn/goji/web"
+	"github.com/zenazn/goji/web/mutil"
+)
+
+// measure is a middleware, that observes the latency and the status
+// of every request by the pattern of its route
+func (server *Server) measure(c *web.C, h http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, req *http.Request) {
+		start := time.Now()
+
+		if c.Env == nil {
+			c.Env = make(map[interface{}]interface{})
+		}
+
+		// the routers add their matched patterns
+		route := new(string)
+		c.Env[routeKey] = route
+
+		ww := mutil.WrapWriter(w)
+		h.ServeHTTP(ww, req)
+
+		if *route == "" {
+			*route = routeUnmatched
+		}
+
+		metrics.ObserveRequest(*route, req.Method, responseStatus(ww), start)
+	}
+
+	return http.HandlerFunc(fn)
+}
+
+// route is a middleware, that adds the pattern matched by a router
+// to the route of the request, it has to follow the router
+func (server *Server) route(c *web.C, h http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, req *http.Request) {
+		route, ok := c.Env[routeKey].(*string)
+		match := web.GetMatch(*c)
+		if ok && match.Pattern != nil {
+			*route = strings.TrimSuffix(*route, "/*") + fmt.Sprint(match.RawPattern())
+		}
+
+		h.ServeHTTP(w, req)
+	}
+
+	return http.HandlerFunc(fn)
+}
+
+// measureJob keeps track of the queue and counts the outcomes of the jobs
+func (server *Server) measureJob(job queue.Job) {
+	switch job.State {
+	case queue.StateQueued:
+		metrics.QueueDepth.Inc()
+	case queue.StateRunning:
+		metrics.QueueDepth.Dec()
+		metrics.ActiveWorkers.Inc()
+	case queue.StateSucceeded, queue.StateFailed, queue.StateInterrupted, queue.StateCanceled:
+		if job.Started != nil {
+			metrics.ActiveWorkers.Dec()
+		} else {
+			metrics.QueueDepth.Dec() // interrupted in the queue
+		}
+		metrics.Jobs.WithLabelValues(job.Kind, job.Request.Universe, job.Request.Name, job.State).Inc()
+	}
+}
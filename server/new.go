@@ -24,10 +24,15 @@ import (
 	"github.com/zenazn/goji/web/middleware"
 	validator "gopkg.in/go-playground/validator.v9"
 
+	"github.com/axelspringer/moppi/audit"
+	"github.com/axelspringer/moppi/auth"
 	"github.com/axelspringer/moppi/cfg"
+	"github.com/axelspringer/moppi/events"
 	"github.com/axelspringer/moppi/installer"
+	"github.com/axelspringer/moppi/metrics"
 	"github.com/axelspringer/moppi/queue"
-	"github.com/axelspringer/moppi/version"
+	"github.com/axelspringer/moppi/signing"
+	"github.com/axelspringer/moppi/webhooks"
 	"github.com/zenazn/goji"
 	"github.com/zenazn/goji/web"
 )
@@ -41,33 +46,76 @@ func New(config *cfg.Config, exit chan bool, wg *sync.WaitGroup) (*Server, error
 func mustNew(config *cfg.Config, exit chan bool, wg *sync.WaitGroup) (*Server, error) {
 	var validate *validator.Validate
 
-	// check version of repo in provider
-	if ok, err := config.Etcd.CheckVersion(version.Version); !ok {
+	installer, err := installer.New(config)
+	if err != nil {
 		return nil, err
 	}
 
-	installer, err := installer.New(config)
+	auth, err := auth.New(config)
+	if err != nil {
+		return nil, err
+	}
+
+	verifier, err := signing.New(config)
+	if err != nil {
+		return nil, err
+	}
+
+	auditLog, err := audit.New(config, &config.Etcd)
 	if err != nil {
 		return nil, err
 	}
 
-	queue := queue.New(1)
+	bus := events.New()
+	dispatcher, err := webhooks.New(config, &config.Etcd, bus)
+	if err != nil {
+		return nil, err
+	}
+
+	proxies, err := newProxies(config.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := queue.NewJobs()
+	queue := queue.New(workers(config), config.QueueCapacity, jobs)
+	queue.Retry(config.Retry)
 	signals := make(chan os.Signal, 1)
 
 	validate = validator.New()
 
 	// server config
 	server := &Server{
-		listener:  config.Listener,
-		installer: installer,
-		signals:   signals,
-		provider:  config.Etcd,
-		queue:     queue,
-		exit:      exit,
-		validator: validate,
-		wg:        wg,
+		listener:   config.Listener,
+		listen:     config.Listen,
+		grpcListen: config.GRPCListen,
+		installer:  installer,
+		auth:       auth,
+		signals:    signals,
+		provider:   config.Etcd,
+		queue:      queue,
+		jobs:       jobs,
+		auditLog:   auditLog,
+		events:     bus,
+		webhooks:   dispatcher,
+		exit:       exit,
+		validator:  validate,
+		verifier:   verifier,
+		config:     config,
+		timeout:    config.ShutdownTimeout,
+		readiness:  &readiness{err: ErrNotReady("the repo has not been checked")},
+		proxies:    proxies,
+		wg:         wg,
 	}
 
+	// the server is done, when it has stopped
+	wg.Add(1)
+
+	// record the outcome of every job
+	jobs.Subscribe(server.auditJob)
+	jobs.Subscribe(server.publishJob)
+	jobs.Subscribe(server.measureJob)
+
 	return server, nil
 }
 
@@ -76,13 +124,8 @@ func (server *Server) ping(c web.C, w http.ResponseWriter, _ *http.Request) {
 	io.WriteString(w, okString)
 }
 
-// health returns health infos about the api
-func (server *Server) health(c web.C, w http.ResponseWriter, _ *http.Request) {
-	return
-}
-
 // installPackage tries to install a package
-func (server *Server) installPackage(w http.ResponseWriter, req *http.Request) {
+func (server *Server) installPackage(c web.C, w http.ResponseWriter, req *http.Request) {
 	req.Header.Add("Accept", "application/json")
 
 	packageRequest, err := parseRequest(req.Body)
@@ -91,20 +134,24 @@ func (server *Server) installPackage(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	pkg, err := server.provider.GetPackage(packageRequest)
-	if err != nil {
-		writeErrorJSON(w, "Could not parse the package request", 400, err)
+	entry := annotate(c, queue.KindInstall, packageRequest)
+	if !server.authorize(c, w, packageRequest.Universe, auth.Install) {
 		return
 	}
 
-	// queue installment
-	server.queue <- &queue.Install{Package: pkg, Installer: server.installer}
+	job, err := server.enqueue(queue.KindInstall, packageRequest, identity(c), server.remoteIP(req), requestID(c))
+	entry.Revision = packageRequest.Revision // revision is resolved now
+	if err != nil {
+		writeEnqueueError(w, "Could not install the package", err)
+		return
+	}
+	entry.Job = job.ID
 
-	w.WriteHeader(201)
+	writeJSONStatus(w, http.StatusCreated, job)
 }
 
 // uninstallPackage tries to uninstall a package
-func (server *Server) uninstallPackage(w http.ResponseWriter, req *http.Request) {
+func (server *Server) uninstallPackage(c web.C, w http.ResponseWriter, req *http.Request) {
 	req.Header.Add("Accept", "application/json")
 
 	packageRequest, err := parseRequest(req.Body)
@@ -113,16 +160,20 @@ func (server *Server) uninstallPackage(w http.ResponseWriter, req *http.Request)
 		return
 	}
 
-	pkg, err := server.provider.GetPackage(packageRequest)
-	if err != nil {
-		writeErrorJSON(w, "Could not parse the package request", 400, err)
+	entry := annotate(c, queue.KindUninstall, packageRequest)
+	if !server.authorize(c, w, packageRequest.Universe, auth.Install) {
 		return
 	}
 
-	// queue installment
-	server.queue <- &queue.Uninstall{Package: pkg, Installer: server.installer}
+	job, err := server.enqueue(queue.KindUninstall, packageRequest, identity(c), server.remoteIP(req), requestID(c))
+	entry.Revision = packageRequest.Revision
+	if err != nil {
+		writeEnqueueError(w, "Could not uninstall the package", err)
+		return
+	}
+	entry.Job = job.ID
 
-	w.WriteHeader(204)
+	writeJSONStatus(w, http.StatusAccepted, job)
 }
 
 // version returns the version of the repository
@@ -139,45 +190,106 @@ func (server *Server) version(w http.ResponseWriter, req *http.Request) {
 func (server *Server) Start() {
 	defer server.wg.Done()
 
-	// add to waiting group
-	server.wg.Add(1)
+	// replace the request ids and the logger of goji
+	goji.Abandon(middleware.RequestID)
+	goji.Abandon(middleware.Logger)
+	goji.Insert(server.requestID, middleware.Recoverer)
+
+	// measure and log every request
+	goji.Use(server.measure)
+	goji.Use(server.access)
 
 	// cors, allow allow for now
 	c := cors.AllowAll()
 	goji.Use(c.Handler)
 
+	// audit and authenticate every request, the rejected ones are audited too
+	goji.Use(server.record)
+	goji.Use(server.authenticate)
+
+	// route before the handlers, to know the pattern of the route
+	goji.Use(goji.DefaultMux.Router)
+	goji.Use(server.route)
+
 	// status
 	goji.Get("/ping", server.ping)
 	goji.Get("/health", server.health)
+	goji.Get("/ready", server.ready)
 	goji.Get("/version", server.version)
+	goji.Get("/metrics", metrics.Handler())
 
 	// triggers
 	goji.Post("/install", server.installPackage)
 	goji.Post("/uninstall", server.uninstallPackage)
 
+	// jobs and audit
+	goji.Get("/jobs", server.getJobs)
+	goji.Get("/jobs/:id", server.getJob)
+	goji.Delete("/jobs/:id", server.cancelJob)
+	goji.Get("/audit", server.getAudit)
+
+	// events
+	goji.Get("/events", server.getEvents)
+	goji.Get("/events/ws", server.getEventsWS)
+
+	// webhooks
+	goji.Get("/webhooks", server.getWebhooks)
+	goji.Post("/webhooks", server.createWebhook)
+	goji.Get("/webhooks/:id", server.getWebhook)
+	goji.Delete("/webhooks/:id", server.deleteWebhook)
+	goji.Get("/webhooks/:id/deliveries", server.getWebhookDeliveries)
+
 	// sub router universes
 	universes := web.New()
 	goji.Get("/universes", server.getUniverses)
 	goji.Post("/universes", server.createUniverse)
 	goji.Handle("/universes/*", universes)
 	universes.Use(middleware.SubRouter)
-	universes.Get("/:universe/meta", server.getUniverse)
-	universes.Delete("/:universe", server.deleteUniverse)
-	universes.Get("/:universe/packages", server.getPkgs)
-	universes.Get("/:universe/packages/:name", server.getPkgRevisions)
-	universes.Delete("/:universe/packages/:name", server.deletePkg)
-	universes.Get("/:universe/packages/:name/:revision", server.getPkg)
-	universes.Post("/:universe/packages/:name", server.createPkgRevision)
-	universes.Delete("/:universe/packages/:name/:revision", server.deletePkgRevision)
-
-	// create server
-	goji.ServeListener(server.listener)
+	universes.Use(universes.Router)
+	universes.Use(server.route)
+	universes.Get("/:universe/meta", server.restrict(auth.Read, server.getUniverse))
+	universes.Delete("/:universe", server.restrict(auth.Admin, server.deleteUniverse))
+	universes.Get("/:universe/packages", server.restrict(auth.Read, server.getPkgs))
+	universes.Get("/:universe/packages/:name", server.restrict(auth.Read, server.getPkgRevisions))
+	universes.Delete("/:universe/packages/:name", server.restrict(auth.Publish, server.yankPkg))
+	universes.Get("/:universe/packages/:name/:revision", server.restrict(auth.Read, server.getPkg))
+	universes.Get("/:universe/packages/:name/:revision/meta", server.restrict(auth.Read, server.getPkgRevision))
+	universes.Post("/:universe/packages/:name", server.restrict(auth.Publish, server.createPkgRevision))
+	universes.Delete("/:universe/packages/:name/:revision", server.restrict(auth.Publish, server.yankPkgRevision))
+
+	// gRPC api, and its REST gateway next to the routes above
+	api := &api{server}
+	gateway, err := server.gateway(api)
+	if err != nil {
+		cfg.Log.WithError(err).Fatal("Could not create the gRPC gateway")
+	}
+	goji.Handle(gatewayPrefix+"*", gateway)
+
+	if server.grpcListen != "" {
+		server.grpc = newGRPC(api)
+		go server.serveGRPC()
+	}
+
+	// sub router admin
+	admin := web.New()
+	goji.Handle("/admin/*", admin)
+	admin.Use(middleware.SubRouter)
+	admin.Use(admin.Router)
+	admin.Use(server.route)
+	admin.Delete("/universes/:universe/packages/:name", server.restrict(auth.Admin, server.purgePkg))
+	admin.Delete("/universes/:universe/packages/:name/:revision", server.restrict(auth.Admin, server.purgePkgRevision))
+
+	// check the repo, publish the changes in the KV, and deliver the webhooks
+	go server.prepare(server.stopped())
+	go server.watch()
+	go server.webhooks.Run(server.stopped())
+
+	// serve, and watch the signals
+	server.serve()
+	server.configSignals()
+	go server.watchSignals()
 
 	// wait for exit
 	<-server.exit
-}
-
-// Stop is stoping to serve the api
-func (server *Server) Stop() {
-	os.Exit(0)
+	server.Stop()
 }
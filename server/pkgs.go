@@ -31,6 +31,16 @@ func (server *Server) getPkg(c web.C, w http.ResponseWriter, _ *http.Request) {
 	pkgRequest.Name = c.URLParams["name"]
 	pkgRequest.Revision = c.URLParams["revision"]
 
+	// resolve the latest revision, that is not yanked
+	if pkgRequest.Revision == provider.RevisionLatest {
+		rev, err := server.provider.LatestRevision(&pkgRequest)
+		if err != nil {
+			writeErrorJSON(w, "Could not retrieve packages", http.StatusNotFound, err)
+			return
+		}
+		pkgRequest.Revision = rev
+	}
+
 	revs, err := server.provider.GetPackage(&pkgRequest)
 	if err != nil {
 		writeErrorJSON(w, "Could not retrieve packages", http.StatusBadRequest, err)
@@ -72,29 +82,79 @@ func (server *Server) getPkgs(c web.C, w http.ResponseWriter, _ *http.Request) {
 	return
 }
 
-// deletePkg deletes a package from a universe
-func (server *Server) deletePkg(c web.C, w http.ResponseWriter, req *http.Request) {
+// getPkgRevision returns the meta infos of a package revision
+func (server *Server) getPkgRevision(c web.C, w http.ResponseWriter, _ *http.Request) {
+	var pkgRequest provider.Request
+	pkgRequest.Universe = c.URLParams["universe"]
+	pkgRequest.Name = c.URLParams["name"]
+	pkgRequest.Revision = c.URLParams["revision"]
+
+	rev, err := server.provider.GetRevision(&pkgRequest)
+	if err != nil {
+		writeErrorJSON(w, "Could not retrieve the revision", http.StatusBadRequest, err)
+		return
+	}
+
+	writeJSON(w, rev)
+	return
+}
+
+// yankPkg yanks all revisions of a package in a universe
+func (server *Server) yankPkg(c web.C, w http.ResponseWriter, req *http.Request) {
+	var pkgRequest provider.Request
+	pkgRequest.Universe = c.URLParams["universe"]
+	pkgRequest.Name = c.URLParams["name"]
+	annotate(c, "package.yank", &pkgRequest)
+
+	if err := server.provider.YankPackage(&pkgRequest); err != nil {
+		writeErrorJSON(w, "Could not yank the package", http.StatusBadRequest, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// yankPkgRevision yanks a revision of a package in a universe
+func (server *Server) yankPkgRevision(c web.C, w http.ResponseWriter, req *http.Request) {
 	var pkgRequest provider.Request
 	pkgRequest.Universe = c.URLParams["universe"]
 	pkgRequest.Name = c.URLParams["name"]
+	pkgRequest.Revision = c.URLParams["revision"]
+	annotate(c, "revision.yank", &pkgRequest)
 
-	if err := server.provider.DeletePackage(&pkgRequest); err != nil {
-		writeErrorJSON(w, "Could not delete the package", http.StatusBadRequest, err)
+	if err := server.provider.YankPackageRevision(&pkgRequest); err != nil {
+		writeErrorJSON(w, "Could not yank the revision", http.StatusBadRequest, err)
 		return
 	}
 
 	w.WriteHeader(http.StatusOK)
 }
 
-// deletePkgRevision deletes a revision of a package in a universe
-func (server *Server) deletePkgRevision(c web.C, w http.ResponseWriter, req *http.Request) {
+// purgePkg removes a package with all its revisions from a universe
+func (server *Server) purgePkg(c web.C, w http.ResponseWriter, req *http.Request) {
+	var pkgRequest provider.Request
+	pkgRequest.Universe = c.URLParams["universe"]
+	pkgRequest.Name = c.URLParams["name"]
+	annotate(c, "package.purge", &pkgRequest)
+
+	if err := server.provider.PurgePackage(&pkgRequest); err != nil {
+		writeErrorJSON(w, "Could not purge the package", http.StatusBadRequest, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// purgePkgRevision removes a revision of a package from a universe
+func (server *Server) purgePkgRevision(c web.C, w http.ResponseWriter, req *http.Request) {
 	var pkgRequest provider.Request
 	pkgRequest.Universe = c.URLParams["universe"]
 	pkgRequest.Name = c.URLParams["name"]
 	pkgRequest.Revision = c.URLParams["revision"]
+	annotate(c, "revision.purge", &pkgRequest)
 
-	if err := server.provider.DeletePackageRevision(&pkgRequest); err != nil {
-		writeErrorJSON(w, "Could not delete the revision", http.StatusBadRequest, err)
+	if err := server.provider.PurgePackageRevision(&pkgRequest); err != nil {
+		writeErrorJSON(w, "Could not purge the revision", http.StatusBadRequest, err)
 		return
 	}
 
@@ -108,6 +168,7 @@ func (server *Server) createPkgRevision(c web.C, w http.ResponseWriter, req *htt
 	var pkgRequest provider.Request
 	pkgRequest.Universe = c.URLParams["universe"]
 	pkgRequest.Name = c.URLParams["name"]
+	entry := annotate(c, "revision.publish", &pkgRequest)
 
 	// empty body
 	if req.Body == nil {
@@ -127,12 +188,26 @@ func (server *Server) createPkgRevision(c web.C, w http.ResponseWriter, req *htt
 		return
 	}
 
+	// verify the detached signature over the canonical package
+	signature := req.Header.Get(signatureHeader)
+	if err := server.verifier.Verify(pkgRequest.Universe, &pkg, signature); err != nil {
+		writeErrorJSON(w, "Could not verify the package signature", http.StatusForbidden, err)
+		return
+	}
+
 	// create new revision
-	rev, err := server.provider.CreatePackageRevision(&pkgRequest, &pkg)
+	rev, err := server.provider.CreatePackageRevision(&pkgRequest, &pkg, signature)
+	if _, ok := err.(provider.ErrRevisionExists); ok {
+		writeErrorJSON(w, "Could not create a new package revision", http.StatusConflict, err)
+		return
+	}
 	if err != nil {
 		writeErrorJSON(w, "Could not create a new package revision", http.StatusBadRequest, err)
+		return
 	}
 
+	entry.Revision = strconv.Itoa(*rev)
+
 	// simply write the newly created revision
 	w.WriteHeader(http.StatusCreated)
 	io.WriteString(w, strconv.Itoa(*rev))
@@ -0,0 +1,126 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"net"
+	"net/http"
+	"strings"
+)
+
+// proxies are the networks of the proxies, whose forwarded addresses are trusted
+type proxies []*net.IPNet
+
+// newProxies parses the trusted proxies of the config, each is an ip or a CIDR
+func newProxies(list []string) (proxies, error) {
+	trusted := make(proxies, 0, len(list))
+	for _, proxy := range list {
+		proxy = strings.TrimSpace(proxy)
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return nil, ErrProxy(proxy)
+			}
+
+			bits := 8 * net.IPv4len
+			if ip.To4() == nil {
+				bits = 8 * net.IPv6len
+			}
+			trusted = append(trusted, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, ErrProxy(proxy)
+		}
+		trusted = append(trusted, network)
+	}
+
+	return trusted, nil
+}
+
+// trusts returns true, when the address is one of a trusted proxy
+func (p proxies) trusts(addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+
+	for _, network := range p {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// client returns the address of the client from the forwarded addresses and
+// the peer. The chain is walked from the right, and the first address, that
+// is not a trusted proxy, is the client
+func (p proxies) client(peer string, forwarded []string) string {
+	addrs := make([]string, 0, len(forwarded)+1)
+	for _, link := range forwarded {
+		for _, addr := range strings.Split(link, ",") {
+			if addr = strings.TrimSpace(addr); addr != "" {
+				addrs = append(addrs, addr)
+			}
+		}
+	}
+
+	if peer != "" {
+		addrs = append(addrs, peer)
+	}
+
+	for i := len(addrs) - 1; i > 0; i-- {
+		if !p.trusts(addrs[i]) {
+			return addrs[i]
+		}
+	}
+
+	if len(addrs) == 0 {
+		return ""
+	}
+
+	return addrs[0]
+}
+
+// remoteIP returns the source ip of a request, the X-Real-IP and
+// X-Forwarded-For headers are only honoured from a trusted proxy
+func (server *Server) remoteIP(req *http.Request) string {
+	addr := host(req.RemoteAddr)
+
+	trusted := server.currentProxies()
+	if !trusted.trusts(addr) {
+		return addr
+	}
+
+	if ip := strings.TrimSpace(req.Header.Get("X-Real-IP")); ip != "" {
+		return ip
+	}
+
+	return trusted.client(addr, req.Header[http.CanonicalHeaderKey("X-Forwarded-For")])
+}
+
+// host returns the host of an address, or the address without a port
+func host(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+
+	return host
+}
@@ -0,0 +1,124 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"reflect"
+
+	"github.com/axelspringer/moppi/auth"
+	"github.com/axelspringer/moppi/cfg"
+	"github.com/axelspringer/moppi/installer"
+)
+
+// reload reads the config again, and applies the settings that can change
+// at runtime, nothing is applied when the new config is not valid
+func (server *Server) reload() {
+	log := cfg.Log
+
+	config, err := cfg.Load()
+	if err != nil {
+		log.WithError(err).Error("Could not reload the config")
+		return
+	}
+
+	installer, err := installer.New(config)
+	if err != nil {
+		log.WithError(err).Error("Could not reload the config of Marathon, Chronos and Mesos")
+		return
+	}
+
+	auth, err := auth.New(config)
+	if err != nil {
+		log.WithError(err).Error("Could not reload the config of the authentication")
+		return
+	}
+
+	proxies, err := newProxies(config.TrustedProxies)
+	if err != nil {
+		log.WithError(err).Error("Could not reload the trusted proxies")
+		return
+	}
+
+	if err := config.InitLog(); err != nil {
+		log.WithError(err).Error("Could not reload the config of the logs")
+		return
+	}
+
+	server.mu.Lock()
+	server.installer = installer
+	server.auth = auth
+	server.proxies = proxies
+	server.mu.Unlock()
+
+	server.queue.Resize(workers(config))
+	server.queue.Limit(config.QueueCapacity)
+	server.queue.Retry(config.Retry)
+	server.webhooks.Configure(config)
+	server.reject(config)
+
+	log.Info("Config reloaded")
+}
+
+// reject warns about the changed settings, that cannot change at runtime
+func (server *Server) reject(config *cfg.Config) {
+	fixed := map[string][2]interface{}{
+		"listen":          {server.config.Listen, config.Listen},
+		"grpclisten":      {server.config.GRPCListen, config.GRPCListen},
+		"etcd.endpoint":   {server.config.Etcd.Endpoint, config.Etcd.Endpoint},
+		"etcd.prefix":     {server.config.Etcd.Prefix, config.Etcd.Prefix},
+		"shutdowntimeout": {server.config.ShutdownTimeout, config.ShutdownTimeout},
+		"signing":         {server.config.Signing, config.Signing},
+		"audit":           {server.config.Audit, config.Audit},
+	}
+
+	for name, values := range fixed {
+		if !reflect.DeepEqual(values[0], values[1]) {
+			cfg.Log.WithField("setting", name).Warn("Setting cannot change at runtime, restart moppi to apply it")
+		}
+	}
+}
+
+// currentInstaller returns the installer of the current config
+func (server *Server) currentInstaller() *installer.Installer {
+	server.mu.RLock()
+	defer server.mu.RUnlock()
+
+	return server.installer
+}
+
+// currentAuth returns the authentication of the current config
+func (server *Server) currentAuth() *auth.Auth {
+	server.mu.RLock()
+	defer server.mu.RUnlock()
+
+	return server.auth
+}
+
+// currentProxies returns the trusted proxies of the current config
+func (server *Server) currentProxies() proxies {
+	server.mu.RLock()
+	defer server.mu.RUnlock()
+
+	return server.proxies
+}
+
+// workers returns the number of workers of a config, at least one
+func workers(config *cfg.Config) int {
+	if config.Workers < 1 {
+		return defaultWorkers
+	}
+
+	return config.Workers
+}
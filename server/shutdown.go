@@ -0,0 +1,83 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	"github.com/axelspringer/moppi/cfg"
+	"github.com/zenazn/goji"
+)
+
+// serve serves the routes of goji, on the listener of the config or on listen
+func (server *Server) serve() {
+	listener := server.listener
+	if listener == nil {
+		var err error
+		if listener, err = net.Listen("tcp", server.listen); err != nil {
+			cfg.Log.WithError(err).Fatalf("Could not listen on %v", server.listen)
+		}
+	}
+
+	goji.DefaultMux.Compile()
+	server.http = &http.Server{Handler: goji.DefaultMux}
+
+	cfg.Log.Infof("Listening on %v", listener.Addr())
+	go func() {
+		if err := server.http.Serve(listener); err != http.ErrServerClosed {
+			cfg.Log.WithError(err).Fatal("Stopped serving")
+		}
+	}()
+}
+
+// Stop stops the server gracefully, it stops accepting requests and lets the
+// requests in flight finish, then stops handing jobs to the workers and waits for
+// the running jobs, jobs that do not finish in time are interrupted
+func (server *Server) Stop() {
+	log := cfg.Log
+	log.Info("Shutting gracefully down")
+
+	ctx, cancel := context.WithTimeout(context.Background(), server.timeout)
+	defer cancel()
+
+	if err := server.http.Shutdown(ctx); err != nil {
+		log.WithError(err).Warn("Requests in flight did not finish")
+	}
+
+	if server.grpc != nil {
+		stopped := make(chan struct{})
+		go func() {
+			server.grpc.GracefulStop()
+			close(stopped)
+		}()
+
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+			server.grpc.Stop()
+		}
+	}
+
+	server.queue.Stop(server.timeout)
+
+	if err := server.auditLog.Close(); err != nil {
+		log.WithError(err).Warn("Could not close the audit log")
+	}
+	server.provider.Close()
+
+	log.Info("Server stopped")
+}
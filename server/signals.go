@@ -21,22 +21,27 @@ import (
 	"github.com/axelspringer/moppi/cfg"
 )
 
-// configSignals configures signals the server should listen to
+// configSignals configures signals the server should listen to,
+// moppi is shut down on SIGINT and SIGTERM by the command
 func (server *Server) configSignals() {
-	signal.Notify(server.signals, syscall.SIGINT, syscall.SIGTERM, syscall.SIGUSR1)
+	signal.Notify(server.signals, syscall.SIGHUP, syscall.SIGUSR1)
 }
 
-// watchSignals is watching configured signals
+// watchSignals is watching configured signals, until the server exits
 func (server *Server) watchSignals() {
+	defer signal.Stop(server.signals)
+
 	log := cfg.Log
 	for {
-		sig := <-server.signals
-		switch sig {
-		case syscall.SIGUSR1:
-			log.Infof("Nothing to see here yet")
-		default:
-			log.Infof("Shutting gracefully down")
-			server.Stop()
+		select {
+		case sig := <-server.signals:
+			switch sig {
+			case syscall.SIGHUP, syscall.SIGUSR1:
+				log.WithField("signal", sig).Info("Reloading the config")
+				server.reload()
+			}
+		case <-server.exit:
+			return
 		}
 	}
 }
@@ -16,30 +16,77 @@ package server
 
 import (
 	"net"
+	"net/http"
 	"os"
 	"sync"
+	"time"
 
+	httpapi "github.com/axelspringer/moppi/api"
+	"github.com/axelspringer/moppi/audit"
+	"github.com/axelspringer/moppi/auth"
+	"github.com/axelspringer/moppi/cfg"
+	"github.com/axelspringer/moppi/events"
 	"github.com/axelspringer/moppi/installer"
 
 	"github.com/axelspringer/moppi/provider/etcd"
 	"github.com/axelspringer/moppi/queue"
+	"github.com/axelspringer/moppi/signing"
+	"github.com/axelspringer/moppi/webhooks"
+	"google.golang.org/grpc"
 	validator "gopkg.in/go-playground/validator.v9"
 )
 
 // Server holds the state of a new Server
 type Server struct {
-	signals   chan os.Signal
-	installer *installer.Installer
-	listener  net.Listener
-	provider  etcd.Provider
-	queue     queue.WorkQueue
-	exit      chan bool
-	validator *validator.Validate
-	wg        *sync.WaitGroup
+	signals    chan os.Signal
+	auth       *auth.Auth
+	installer  *installer.Installer
+	listener   net.Listener
+	listen     string
+	http       *http.Server
+	grpcListen string
+	grpc       *grpc.Server
+	provider   etcd.Provider
+	queue      *queue.Queue
+	jobs       *queue.Jobs
+	auditLog   *audit.Log
+	events     *events.Bus
+	webhooks   *webhooks.Dispatcher
+	exit       chan bool
+	validator  *validator.Validate
+	verifier   *signing.Verifier
+	config     *cfg.Config
+	timeout    time.Duration
+	readiness  *readiness
+	proxies    proxies
+	wg         *sync.WaitGroup
+	mu         sync.RWMutex
+}
+
+// api implements the services of the gRPC api on top of the server
+type api struct {
+	server *Server
 }
 
 // Error contains an error of the api
-type Error struct {
-	Msg string
-	Err string
+type Error = httpapi.Error
+
+// Health is the health report of the server and of the components it depends on
+type Health struct {
+	Status     string                      `json:"status"`
+	Components map[string]*ComponentHealth `json:"components"`
+}
+
+// ComponentHealth is the health of a component, the latency is in milliseconds
+type ComponentHealth struct {
+	Status  string  `json:"status"`
+	Latency float64 `json:"latency_ms"`
+	Detail  string  `json:"detail,omitempty"`
+	Error   string  `json:"error,omitempty"`
+}
+
+// readiness holds the reason, why the server is not ready yet
+type readiness struct {
+	err error
+	mu  sync.RWMutex
 }
@@ -17,7 +17,10 @@ package server
 import (
 	"encoding/json"
 	"net/http"
+	"path"
+	"strings"
 
+	"github.com/axelspringer/moppi/auth"
 	"github.com/axelspringer/moppi/provider"
 	"github.com/zenazn/goji/web"
 )
@@ -57,6 +60,11 @@ func (server *Server) createUniverse(c web.C, w http.ResponseWriter, req *http.R
 		return
 	}
 
+	annotate(c, "universe.create", &provider.Request{Universe: strings.ToLower(universe.Name)})
+	if !server.authorize(c, w, strings.ToLower(universe.Name), auth.Admin) {
+		return
+	}
+
 	err = server.provider.CreateUniverse(&universe)
 	if err != nil {
 		writeErrorJSON(w, "Could not create a new universe", http.StatusBadGateway, err)
@@ -70,6 +78,7 @@ func (server *Server) createUniverse(c web.C, w http.ResponseWriter, req *http.R
 func (server *Server) deleteUniverse(c web.C, w http.ResponseWriter, req *http.Request) {
 	var pkgRequest provider.Request
 	pkgRequest.Universe = c.URLParams["universe"]
+	annotate(c, "universe.delete", &pkgRequest)
 
 	if err := server.provider.DeleteUniverse(&pkgRequest); err != nil {
 		writeErrorJSON(w, "Could not delete the universe", 400, err)
@@ -80,14 +89,22 @@ func (server *Server) deleteUniverse(c web.C, w http.ResponseWriter, req *http.R
 	return
 }
 
-// getUniverses returns all the known universes
-func (server *Server) getUniverses(w http.ResponseWriter, _ *http.Request) {
+// getUniverses returns all the known universes, the caller can read
+func (server *Server) getUniverses(c web.C, w http.ResponseWriter, _ *http.Request) {
 	universes, err := server.provider.GetUniverses()
 	if err != nil {
 		writeErrorJSON(w, "Could not retrieve the universes", 400, err)
 		return
 	}
 
-	writeJSON(w, universes)
+	identity := identity(c)
+	readable := make(provider.Universes, 0, len(*universes))
+	for _, universe := range *universes {
+		if identity.Can(path.Base(universe.Href), auth.Read) {
+			readable = append(readable, universe)
+		}
+	}
+
+	writeJSON(w, readable)
 	return
 }
@@ -0,0 +1,128 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/axelspringer/moppi/auth"
+	"github.com/axelspringer/moppi/provider"
+	"github.com/axelspringer/moppi/webhooks"
+	"github.com/docker/libkv/store"
+	"github.com/zenazn/goji/web"
+)
+
+// getWebhooks returns all webhooks, without their secrets
+func (server *Server) getWebhooks(c web.C, w http.ResponseWriter, _ *http.Request) {
+	if !server.authorize(c, w, auth.AnyUniverse, auth.Admin) {
+		return
+	}
+
+	hooks, err := server.provider.GetWebhooks()
+	if err != nil {
+		writeErrorJSON(w, "Could not retrieve the webhooks", http.StatusBadGateway, err)
+		return
+	}
+
+	for _, hook := range hooks {
+		hook.Secret = ""
+	}
+
+	writeJSON(w, hooks)
+}
+
+// getWebhook returns a webhook, without its secret
+func (server *Server) getWebhook(c web.C, w http.ResponseWriter, _ *http.Request) {
+	if !server.authorize(c, w, auth.AnyUniverse, auth.Admin) {
+		return
+	}
+
+	hook, err := server.provider.GetWebhook(c.URLParams["id"])
+	if err == store.ErrKeyNotFound {
+		writeErrorJSON(w, "Could not retrieve the webhook", http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		writeErrorJSON(w, "Could not retrieve the webhook", http.StatusBadGateway, err)
+		return
+	}
+	hook.Secret = ""
+
+	writeJSON(w, hook)
+}
+
+// createWebhook registers a new webhook
+func (server *Server) createWebhook(c web.C, w http.ResponseWriter, req *http.Request) {
+	entry := annotate(c, "webhook.create", nil)
+	if !server.authorize(c, w, auth.AnyUniverse, auth.Admin) {
+		return
+	}
+
+	var hook provider.Webhook
+	if err := json.NewDecoder(req.Body).Decode(&hook); err != nil {
+		writeErrorJSON(w, "Could not parse the request", http.StatusBadRequest, err)
+		return
+	}
+
+	if err := server.validator.Struct(hook); err != nil {
+		writeErrorJSON(w, "Could not create the webhook", http.StatusBadRequest, err)
+		return
+	}
+
+	if err := webhooks.Validate(&hook); err != nil {
+		writeErrorJSON(w, "Could not create the webhook", http.StatusBadRequest, err)
+		return
+	}
+
+	hook.ID = newID()
+	hook.Created = time.Now().UTC()
+	entry.Universe = hook.Universe
+
+	if err := server.provider.CreateWebhook(&hook); err != nil {
+		writeErrorJSON(w, "Could not create the webhook", http.StatusBadGateway, err)
+		return
+	}
+	hook.Secret = ""
+
+	writeJSONStatus(w, http.StatusCreated, hook)
+}
+
+// deleteWebhook removes a webhook
+func (server *Server) deleteWebhook(c web.C, w http.ResponseWriter, _ *http.Request) {
+	id := c.URLParams["id"]
+	annotate(c, "webhook.delete", nil)
+	if !server.authorize(c, w, auth.AnyUniverse, auth.Admin) {
+		return
+	}
+
+	if err := server.provider.DeleteWebhook(id); err != nil {
+		writeErrorJSON(w, "Could not delete the webhook", http.StatusBadRequest, err)
+		return
+	}
+	server.webhooks.Forget(id)
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// getWebhookDeliveries returns the recent deliveries to a webhook, newest first
+func (server *Server) getWebhookDeliveries(c web.C, w http.ResponseWriter, _ *http.Request) {
+	if !server.authorize(c, w, auth.AnyUniverse, auth.Admin) {
+		return
+	}
+
+	writeJSON(w, server.webhooks.Deliveries(c.URLParams["id"]))
+}
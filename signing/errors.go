@@ -0,0 +1,49 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signing
+
+import "fmt"
+
+// ErrUnsigned is returned when an unsigned package is installed in a universe that requires signing
+type ErrUnsigned string
+
+// Error returns a custom error
+func (err ErrUnsigned) Error() string {
+	return fmt.Sprintf("Universe %v requires signed packages", string(err))
+}
+
+// ErrBadSignature is returned when a signature could not be verified
+type ErrBadSignature string
+
+// Error returns a custom error
+func (err ErrBadSignature) Error() string {
+	return fmt.Sprintf("The signature could not be verified with a trusted key of universe %v", string(err))
+}
+
+// ErrMalformed is returned when a signature or key could not be parsed
+type ErrMalformed string
+
+// Error returns a custom error
+func (err ErrMalformed) Error() string {
+	return fmt.Sprintf("Malformed signature or key: %v", string(err))
+}
+
+// ErrEncrypted is returned when a PGP key to sign with is encrypted
+type ErrEncrypted string
+
+// Error returns a custom error
+func (err ErrEncrypted) Error() string {
+	return fmt.Sprintf("The PGP key %v is encrypted, decrypt it to sign", string(err))
+}
@@ -0,0 +1,91 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signing
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/axelspringer/moppi/cfg"
+	"golang.org/x/crypto/ed25519"
+	"golang.org/x/crypto/openpgp"
+)
+
+// New creates a new Verifier from the configured trusted keys
+func New(config *cfg.Config) (*Verifier, error) {
+	return mustNew(config.Signing)
+}
+
+// mustNew wraps the creation of a new Verifier
+func mustNew(signing map[string]*cfg.Signing) (*Verifier, error) {
+	verifier := &Verifier{
+		universes: make(map[string]*Keyring),
+	}
+
+	for universe, config := range signing {
+		if config == nil {
+			continue
+		}
+
+		keyring := &Keyring{Required: config.Required}
+		for _, key := range config.Keys {
+			if err := keyring.add(key); err != nil {
+				return nil, err
+			}
+		}
+
+		verifier.universes[strings.ToLower(universe)] = keyring
+	}
+
+	return verifier, nil
+}
+
+// add parses a key and adds it to the keyring
+func (k *Keyring) add(key string) error {
+	parts := strings.SplitN(key, ":", 2)
+	if len(parts) != 2 {
+		return ErrMalformed(key)
+	}
+
+	switch parts[0] {
+	case Ed25519:
+		pub, err := base64.StdEncoding.DecodeString(parts[1])
+		if err != nil || len(pub) != ed25519.PublicKeySize {
+			return ErrMalformed(key)
+		}
+		k.Ed25519 = append(k.Ed25519, ed25519.PublicKey(pub))
+	case PGP:
+		armored := []byte(parts[1])
+		if _, err := os.Stat(parts[1]); err == nil {
+			armored, err = ioutil.ReadFile(parts[1])
+			if err != nil {
+				return err
+			}
+		}
+
+		entities, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(armored))
+		if err != nil {
+			return err
+		}
+		k.PGP = append(k.PGP, entities...)
+	default:
+		return ErrMalformed(key)
+	}
+
+	return nil
+}
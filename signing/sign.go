@@ -0,0 +1,93 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signing
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/axelspringer/moppi/provider"
+	"golang.org/x/crypto/ed25519"
+	"golang.org/x/crypto/openpgp"
+)
+
+// Sign signs the canonical document of a package with a private key, and
+// returns the signature in the form of <type>:<base64>. The key is either
+// ed25519:<base64 of the seed or private key> or pgp:<armored key or path>
+func Sign(pkg *provider.Package, key string) (string, error) {
+	doc, err := provider.Canonical(pkg)
+	if err != nil {
+		return "", err
+	}
+
+	parts := strings.SplitN(strings.TrimSpace(key), ":", 2)
+	if len(parts) != 2 {
+		return "", ErrMalformed("private key")
+	}
+
+	switch parts[0] {
+	case Ed25519:
+		raw, err := base64.StdEncoding.DecodeString(parts[1])
+		if err != nil {
+			return "", ErrMalformed("private key")
+		}
+
+		var priv ed25519.PrivateKey
+		switch len(raw) {
+		case ed25519.SeedSize:
+			priv = ed25519.NewKeyFromSeed(raw)
+		case ed25519.PrivateKeySize:
+			priv = ed25519.PrivateKey(raw)
+		default:
+			return "", ErrMalformed("private key")
+		}
+
+		return Ed25519 + ":" + base64.StdEncoding.EncodeToString(ed25519.Sign(priv, doc)), nil
+	case PGP:
+		armored := []byte(parts[1])
+		if _, err := os.Stat(parts[1]); err == nil {
+			armored, err = ioutil.ReadFile(parts[1])
+			if err != nil {
+				return "", err
+			}
+		}
+
+		entities, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(armored))
+		if err != nil {
+			return "", err
+		}
+
+		if len(entities) == 0 || entities[0].PrivateKey == nil {
+			return "", ErrMalformed("private key")
+		}
+
+		signer := entities[0]
+		if signer.PrivateKey.Encrypted {
+			return "", ErrEncrypted(signer.PrimaryKey.KeyIdString())
+		}
+
+		var sig bytes.Buffer
+		if err := openpgp.DetachSign(&sig, signer, bytes.NewReader(doc), nil); err != nil {
+			return "", err
+		}
+
+		return PGP + ":" + base64.StdEncoding.EncodeToString(sig.Bytes()), nil
+	default:
+		return "", ErrMalformed("private key")
+	}
+}
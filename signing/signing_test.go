@@ -0,0 +1,179 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signing_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/axelspringer/moppi/cfg"
+	"github.com/axelspringer/moppi/provider"
+	"github.com/axelspringer/moppi/signing"
+	"golang.org/x/crypto/ed25519"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+)
+
+// armored returns an armored PGP key of an entity
+func armored(entity *openpgp.Entity, private bool) string {
+	var buf bytes.Buffer
+
+	blockType := openpgp.PublicKeyType
+	if private {
+		blockType = openpgp.PrivateKeyType
+	}
+
+	w, err := armor.Encode(&buf, blockType, nil)
+	Expect(err).NotTo(HaveOccurred())
+
+	if private {
+		Expect(entity.SerializePrivate(w, nil)).To(Succeed())
+	} else {
+		Expect(entity.Serialize(w)).To(Succeed())
+	}
+	Expect(w.Close()).To(Succeed())
+
+	return buf.String()
+}
+
+var _ = Describe("Signing", func() {
+	var (
+		pkg      *provider.Package
+		seed     []byte
+		pub      ed25519.PublicKey
+		verifier *signing.Verifier
+	)
+
+	BeforeEach(func() {
+		var err error
+		pkg = &provider.Package{Install: provider.Install{Marathon: true}}
+
+		seed = make([]byte, ed25519.SeedSize)
+		_, err = rand.Read(seed)
+		Expect(err).NotTo(HaveOccurred())
+		pub = ed25519.NewKeyFromSeed(seed).Public().(ed25519.PublicKey)
+
+		verifier, err = signing.New(&cfg.Config{Signing: map[string]*cfg.Signing{
+			"prod": {Required: true, Keys: []string{"ed25519:" + base64.StdEncoding.EncodeToString(pub)}},
+			"dev":  {Keys: []string{"ed25519:" + base64.StdEncoding.EncodeToString(pub)}},
+		}})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Context("ed25519", func() {
+		It("verifies a good signature", func() {
+			signature, err := signing.Sign(pkg, "ed25519:"+base64.StdEncoding.EncodeToString(seed))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(verifier.Verify("prod", pkg, signature)).To(Succeed())
+			Expect(verifier.Verify("PROD", pkg, signature)).To(Succeed())
+		})
+
+		It("rejects a signature of another package", func() {
+			signature, err := signing.Sign(pkg, "ed25519:"+base64.StdEncoding.EncodeToString(seed))
+			Expect(err).NotTo(HaveOccurred())
+
+			pkg.Install.Chronos = true
+			Expect(verifier.Verify("prod", pkg, signature)).To(Equal(signing.ErrBadSignature("prod")))
+		})
+
+		It("rejects a signature of an untrusted key", func() {
+			_, other, err := ed25519.GenerateKey(rand.Reader)
+			Expect(err).NotTo(HaveOccurred())
+
+			signature, err := signing.Sign(pkg, "ed25519:"+base64.StdEncoding.EncodeToString(other))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(verifier.Verify("prod", pkg, signature)).To(Equal(signing.ErrBadSignature("prod")))
+		})
+
+		It("rejects a malformed signature", func() {
+			Expect(verifier.Verify("prod", pkg, "ed25519:!!!")).To(BeAssignableToTypeOf(signing.ErrMalformed("")))
+			Expect(verifier.Verify("prod", pkg, "rsa:AAAA")).To(BeAssignableToTypeOf(signing.ErrMalformed("")))
+			Expect(verifier.Verify("prod", pkg, "AAAA")).To(BeAssignableToTypeOf(signing.ErrMalformed("")))
+		})
+
+		It("rejects an unsigned package only, where signing is required", func() {
+			Expect(verifier.Verify("prod", pkg, "")).To(Equal(signing.ErrUnsigned("prod")))
+			Expect(verifier.Verify("dev", pkg, "")).To(Succeed())
+			Expect(verifier.Verify("staging", pkg, "")).To(Succeed())
+		})
+
+		It("rejects a malformed key", func() {
+			_, err := signing.New(&cfg.Config{Signing: map[string]*cfg.Signing{
+				"prod": {Keys: []string{"ed25519:AAAA"}},
+			}})
+			Expect(err).To(BeAssignableToTypeOf(signing.ErrMalformed("")))
+		})
+	})
+
+	Context("PGP", func() {
+		var entity *openpgp.Entity
+
+		BeforeEach(func() {
+			var err error
+			entity, err = openpgp.NewEntity("release", "", "release@example.com", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			verifier, err = signing.New(&cfg.Config{Signing: map[string]*cfg.Signing{
+				"prod": {Required: true, Keys: []string{"pgp:" + armored(entity, false)}},
+			}})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("verifies a good binary signature", func() {
+			signature, err := signing.Sign(pkg, "pgp:"+armored(entity, true))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(verifier.Verify("prod", pkg, signature)).To(Succeed())
+		})
+
+		It("verifies a good armored signature", func() {
+			doc, err := provider.Canonical(pkg)
+			Expect(err).NotTo(HaveOccurred())
+
+			var sig bytes.Buffer
+			Expect(openpgp.ArmoredDetachSign(&sig, entity, bytes.NewReader(doc), nil)).To(Succeed())
+
+			Expect(verifier.Verify("prod", pkg, "pgp:"+base64.StdEncoding.EncodeToString(sig.Bytes()))).To(Succeed())
+		})
+
+		It("rejects a signature of another package", func() {
+			signature, err := signing.Sign(pkg, "pgp:"+armored(entity, true))
+			Expect(err).NotTo(HaveOccurred())
+
+			pkg.Install.Chronos = true
+			Expect(verifier.Verify("prod", pkg, signature)).To(Equal(signing.ErrBadSignature("prod")))
+		})
+
+		It("rejects a malformed signature", func() {
+			signature := "pgp:" + base64.StdEncoding.EncodeToString([]byte("not a signature"))
+			Expect(verifier.Verify("prod", pkg, signature)).To(Equal(signing.ErrBadSignature("prod")))
+		})
+
+		It("rejects an unsigned package", func() {
+			Expect(verifier.Verify("prod", pkg, "")).To(Equal(signing.ErrUnsigned("prod")))
+		})
+
+		It("does not sign with a public key", func() {
+			_, err := signing.Sign(pkg, "pgp:"+armored(entity, false))
+			Expect(err).To(BeAssignableToTypeOf(signing.ErrMalformed("")))
+		})
+	})
+})
@@ -0,0 +1,38 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signing
+
+import (
+	"golang.org/x/crypto/ed25519"
+	"golang.org/x/crypto/openpgp"
+)
+
+// Verifier verifies publisher signatures against the trusted keys of universes
+type Verifier struct {
+	universes map[string]*Keyring
+}
+
+// Keyring holds the trusted keys of a universe
+type Keyring struct {
+	Required bool
+	Ed25519  []ed25519.PublicKey
+	PGP      openpgp.EntityList
+}
+
+// Signature is a detached signature over a canonical package document
+type Signature struct {
+	Type  string
+	Value []byte
+}
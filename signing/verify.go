@@ -0,0 +1,110 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signing
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+
+	"github.com/axelspringer/moppi/provider"
+	"golang.org/x/crypto/ed25519"
+	"golang.org/x/crypto/openpgp"
+)
+
+// ParseSignature parses a signature in the form of <type>:<base64>
+func ParseSignature(s string) (*Signature, error) {
+	parts := strings.SplitN(s, ":", 2)
+	if len(parts) != 2 {
+		return nil, ErrMalformed(s)
+	}
+
+	value, err := base64.StdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrMalformed(s)
+	}
+
+	switch parts[0] {
+	case Ed25519, PGP:
+		return &Signature{Type: parts[0], Value: value}, nil
+	default:
+		return nil, ErrMalformed(s)
+	}
+}
+
+// Required returns if a universe requires signed packages
+func (v *Verifier) Required(universe string) bool {
+	keyring, ok := v.universes[strings.ToLower(universe)]
+	return ok && keyring.Required
+}
+
+// Verify verifies the signature over the canonical document of a package
+// with the trusted keys of the universe. Unsigned packages only pass
+// in universes that do not require signing.
+func (v *Verifier) Verify(universe string, pkg *provider.Package, signature string) error {
+	keyring, ok := v.universes[strings.ToLower(universe)]
+	if !ok {
+		return nil // noop, no trusted keys
+	}
+
+	if signature == "" {
+		if keyring.Required {
+			return ErrUnsigned(universe)
+		}
+		return nil
+	}
+
+	sig, err := ParseSignature(signature)
+	if err != nil {
+		return err
+	}
+
+	doc, err := provider.Canonical(pkg)
+	if err != nil {
+		return err
+	}
+
+	if !keyring.verify(doc, sig) {
+		return ErrBadSignature(universe)
+	}
+
+	return nil
+}
+
+// verify checks a signature against all the keys of its type
+func (k *Keyring) verify(doc []byte, sig *Signature) bool {
+	switch sig.Type {
+	case Ed25519:
+		for _, key := range k.Ed25519 {
+			if ed25519.Verify(key, doc, sig.Value) {
+				return true
+			}
+		}
+	case PGP:
+		if len(k.PGP) == 0 {
+			return false
+		}
+
+		// signatures may be armored or binary
+		if _, err := openpgp.CheckArmoredDetachedSignature(k.PGP, bytes.NewReader(doc), bytes.NewReader(sig.Value)); err == nil {
+			return true
+		}
+		if _, err := openpgp.CheckDetachedSignature(k.PGP, bytes.NewReader(doc), bytes.NewReader(sig.Value)); err == nil {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,58 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhooks
+
+import "time"
+
+const (
+	// EventRevisionPublished is sent when a revision of a package is published
+	EventRevisionPublished = "revision.published"
+	// EventInstallSucceeded is sent when an installment succeeded
+	EventInstallSucceeded = "install.succeeded"
+	// EventInstallFailed is sent when an installment failed
+	EventInstallFailed = "install.failed"
+	// EventUninstallSucceeded is sent when an uninstallment succeeded
+	EventUninstallSucceeded = "uninstall.succeeded"
+	// EventUninstallFailed is sent when an uninstallment failed
+	EventUninstallFailed = "uninstall.failed"
+)
+
+const (
+	// StatePending is the state of a delivery, that is tried
+	StatePending = "pending"
+	// StateDelivered is the state of a successful delivery
+	StateDelivered = "delivered"
+	// StateFailed is the state of a delivery, that failed all its attempts
+	StateFailed = "failed"
+)
+
+const (
+	// SignatureHeader carries the HMAC-SHA256 of the payload with the secret
+	SignatureHeader = "X-Moppi-Signature-256"
+	// EventHeader carries the event of the payload
+	EventHeader = "X-Moppi-Event"
+	// DeliveryHeader carries the id of the delivery
+	DeliveryHeader = "X-Moppi-Delivery"
+)
+
+const (
+	defaultRetries      = 5
+	defaultTimeout      = 10 * time.Second
+	retryBackoff        = time.Second
+	maxRetryBackoff     = 5 * time.Minute
+	deliveriesRetention = 50
+	signaturePrefix     = "sha256="
+	userAgent           = "moppi-webhooks"
+)
@@ -0,0 +1,33 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhooks
+
+import "fmt"
+
+// ErrUnknownEvent is returned when a webhook subscribes to an unknown event
+type ErrUnknownEvent string
+
+// Error returns a custom error
+func (err ErrUnknownEvent) Error() string {
+	return fmt.Sprintf("Unknown event %v", string(err))
+}
+
+// ErrDelivery is returned when a webhook responds with an error status
+type ErrDelivery int
+
+// Error returns a custom error
+func (err ErrDelivery) Error() string {
+	return fmt.Sprintf("The webhook responded with status %d", int(err))
+}
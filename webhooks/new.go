@@ -0,0 +1,63 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhooks
+
+import (
+	"net/http"
+
+	"github.com/axelspringer/moppi/cfg"
+	"github.com/axelspringer/moppi/events"
+)
+
+// New creates a new Dispatcher of the webhooks in the store
+func New(config *cfg.Config, store Store, bus *events.Bus) (*Dispatcher, error) {
+	return mustNew(&config.Webhooks, store, bus)
+}
+
+// mustNew wraps the creation of a new Dispatcher
+func mustNew(config *cfg.Webhooks, store Store, bus *events.Bus) (*Dispatcher, error) {
+	dispatcher := &Dispatcher{
+		store:      store,
+		deliveries: make(map[string][]*Delivery),
+	}
+	dispatcher.configure(config)
+
+	// subscribe right away, the events until it runs are buffered
+	dispatcher.sub = bus.Subscribe(events.Filter{Types: []string{events.TypeJob, events.TypeRevision}}, 0)
+
+	return dispatcher, nil
+}
+
+// Configure applies the timeout and the retries of a new config,
+// deliveries in progress keep their settings
+func (d *Dispatcher) Configure(config *cfg.Config) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.configure(&config.Webhooks)
+}
+
+// configure sets the timeout and the retries, or their defaults
+func (d *Dispatcher) configure(config *cfg.Webhooks) {
+	d.client = &http.Client{Timeout: config.Timeout}
+	if d.client.Timeout <= 0 {
+		d.client.Timeout = defaultTimeout
+	}
+
+	d.retries = config.Retries
+	if d.retries <= 0 {
+		d.retries = defaultRetries
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhooks
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/axelspringer/moppi/events"
+	"github.com/axelspringer/moppi/provider"
+)
+
+// Store persists the webhooks (e.g. the KV provider)
+type Store interface {
+	GetWebhooks() ([]*provider.Webhook, error)
+}
+
+// Dispatcher delivers the events to the subscribed webhooks
+type Dispatcher struct {
+	store      Store
+	sub        *events.Subscription
+	client     *http.Client
+	retries    int
+	deliveries map[string][]*Delivery
+	mu         sync.RWMutex
+}
+
+// Delivery describes the delivery of a payload to a webhook
+type Delivery struct {
+	ID       string     `json:"id"`
+	Webhook  string     `json:"webhook"`
+	Event    string     `json:"event"`
+	State    string     `json:"state"`
+	Attempts int        `json:"attempts"`
+	Status   int        `json:"status,omitempty"`
+	Error    string     `json:"error,omitempty"`
+	Created  time.Time  `json:"created"`
+	Finished *time.Time `json:"finished,omitempty"`
+}
+
+// Payload is the signed JSON, that is posted to a webhook
+type Payload struct {
+	ID       string    `json:"id"`
+	Event    string    `json:"event"`
+	Time     time.Time `json:"time"`
+	Universe string    `json:"universe"`
+	Package  string    `json:"package,omitempty"`
+	Revision string    `json:"revision,omitempty"`
+	Job      string    `json:"job,omitempty"`
+	Identity string    `json:"identity,omitempty"`
+	Error    string    `json:"error,omitempty"`
+}
@@ -0,0 +1,256 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhooks
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/axelspringer/moppi/cfg"
+	"github.com/axelspringer/moppi/events"
+	"github.com/axelspringer/moppi/provider"
+	"github.com/axelspringer/moppi/queue"
+)
+
+// Run delivers the events of the bus to the subscribed webhooks until stop is closed
+func (d *Dispatcher) Run(stop <-chan struct{}) {
+	defer d.sub.Close()
+
+	for {
+		select {
+		case event := <-d.sub.C:
+			name, ok := eventName(event)
+			if !ok {
+				continue
+			}
+
+			webhooks, err := d.store.GetWebhooks()
+			if err != nil {
+				cfg.Log.WithError(err).Error("Could not retrieve the webhooks")
+				continue
+			}
+
+			payload := &Payload{
+				ID:       newID(),
+				Event:    name,
+				Time:     event.Time,
+				Universe: event.Universe,
+				Package:  event.Package,
+				Revision: event.Revision,
+				Job:      event.Job,
+				Identity: event.Identity,
+				Error:    event.Error,
+			}
+
+			for _, webhook := range webhooks {
+				if subscribed(webhook, payload) {
+					go d.deliver(webhook, payload, stop)
+				}
+			}
+		case <-stop:
+			return
+		}
+	}
+}
+
+// Deliveries returns the recent deliveries to a webhook, newest first
+func (d *Dispatcher) Deliveries(webhook string) []Delivery {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	deliveries := d.deliveries[webhook]
+	snapshot := make([]Delivery, 0, len(deliveries))
+	for i := len(deliveries) - 1; i >= 0; i-- {
+		snapshot = append(snapshot, *deliveries[i])
+	}
+
+	return snapshot
+}
+
+// Forget drops the deliveries to a removed webhook
+func (d *Dispatcher) Forget(webhook string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.deliveries, webhook)
+}
+
+// Validate checks the events of a webhook
+func Validate(webhook *provider.Webhook) error {
+	for _, event := range webhook.Events {
+		switch event {
+		case EventRevisionPublished, EventInstallSucceeded, EventInstallFailed, EventUninstallSucceeded, EventUninstallFailed:
+		default:
+			return ErrUnknownEvent(event)
+		}
+	}
+
+	return nil
+}
+
+// deliver posts the payload to a webhook, a failed attempt is retried with an exponential backoff
+func (d *Dispatcher) deliver(webhook *provider.Webhook, payload *Payload, stop <-chan struct{}) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return
+	}
+
+	delivery := d.track(webhook.ID, payload)
+	backoff := retryBackoff
+
+	d.mu.RLock()
+	client, retries := d.client, d.retries
+	d.mu.RUnlock()
+
+	for attempt := 1; attempt <= retries+1; attempt++ {
+		status, err := d.post(client, webhook, payload, body)
+		d.attempted(delivery, status, err, attempt > retries)
+		if err == nil {
+			return
+		}
+
+		if attempt > retries {
+			cfg.Log.WithError(err).Warnf("Could not deliver %v to webhook %v", payload.Event, webhook.ID)
+			return
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-stop:
+			return
+		}
+
+		if backoff *= 2; backoff > maxRetryBackoff {
+			backoff = maxRetryBackoff
+		}
+	}
+}
+
+// post sends the signed payload to a webhook
+func (d *Dispatcher) post(client *http.Client, webhook *provider.Webhook, payload *Payload, body []byte) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(EventHeader, payload.Event)
+	req.Header.Set(DeliveryHeader, payload.ID)
+	if webhook.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(webhook.Secret, body))
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return res.StatusCode, ErrDelivery(res.StatusCode)
+	}
+
+	return res.StatusCode, nil
+}
+
+// track adds a pending delivery to a webhook
+func (d *Dispatcher) track(webhook string, payload *Payload) *Delivery {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delivery := &Delivery{
+		ID:      payload.ID,
+		Webhook: webhook,
+		Event:   payload.Event,
+		State:   StatePending,
+		Created: time.Now().UTC(),
+	}
+
+	deliveries := append(d.deliveries[webhook], delivery)
+	if len(deliveries) > deliveriesRetention {
+		deliveries = deliveries[len(deliveries)-deliveriesRetention:]
+	}
+	d.deliveries[webhook] = deliveries
+
+	return delivery
+}
+
+// attempted records an attempt of a delivery
+func (d *Dispatcher) attempted(delivery *Delivery, status int, err error, last bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delivery.Attempts++
+	delivery.Status = status
+	delivery.Error = ""
+
+	switch {
+	case err == nil:
+		delivery.State = StateDelivered
+	case last:
+		delivery.State = StateFailed
+	}
+
+	if err != nil {
+		delivery.Error = err.Error()
+	}
+
+	if delivery.State != StatePending {
+		now := time.Now().UTC()
+		delivery.Finished = &now
+	}
+}
+
+// eventName maps an event of the bus to the event of a webhook
+func eventName(event events.Event) (string, bool) {
+	switch event.Type {
+	case events.TypeRevision:
+		// a change in the KV, also when published directly in the KV
+		if event.Source == events.SourceKV && event.Type+"."+event.State == provider.ChangeRevisionAdded {
+			return EventRevisionPublished, true
+		}
+	case events.TypeJob:
+		switch event.State {
+		case queue.StateSucceeded, queue.StateFailed:
+			if event.Kind == queue.KindInstall || event.Kind == queue.KindUninstall {
+				return event.Kind + "." + event.State, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// subscribed checks if a webhook is subscribed to the payload
+func subscribed(webhook *provider.Webhook, payload *Payload) bool {
+	if webhook.Universe != "" && webhook.Universe != payload.Universe {
+		return false
+	}
+
+	if len(webhook.Events) == 0 {
+		return true
+	}
+
+	for _, event := range webhook.Events {
+		if event == payload.Event {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,114 @@
+// Copyright 2017 Axel Springer SE
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhooks_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/axelspringer/moppi/cfg"
+	"github.com/axelspringer/moppi/events"
+	"github.com/axelspringer/moppi/provider"
+	"github.com/axelspringer/moppi/queue"
+	"github.com/axelspringer/moppi/webhooks"
+)
+
+// store is a static store of webhooks
+type store []*provider.Webhook
+
+func (s store) GetWebhooks() ([]*provider.Webhook, error) {
+	return s, nil
+}
+
+// delivery is a request, that was received by a webhook
+type delivery struct {
+	header http.Header
+	body   []byte
+}
+
+var _ = Describe("Dispatcher", func() {
+	var (
+		bus        *events.Bus
+		stop       chan struct{}
+		ts         *httptest.Server
+		attempts   int32
+		deliveries chan delivery
+	)
+
+	BeforeEach(func() {
+		bus = events.New()
+		stop = make(chan struct{})
+		attempts = 0
+		deliveries = make(chan delivery, 1)
+
+		// fails the first attempt
+		ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if atomic.AddInt32(&attempts, 1) == 1 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+
+			body, _ := ioutil.ReadAll(req.Body)
+			deliveries <- delivery{header: req.Header, body: body}
+		}))
+	})
+
+	AfterEach(func() {
+		close(stop)
+		ts.Close()
+	})
+
+	It("retries and signs the delivery of a subscribed event", func() {
+		hook := &provider.Webhook{ID: "chatbot", URL: ts.URL, Secret: "s3cr3t", Universe: "prod", Events: []string{webhooks.EventInstallSucceeded}}
+		dispatcher, err := webhooks.New(&cfg.Config{}, store{hook}, bus)
+		Expect(err).NotTo(HaveOccurred())
+		go dispatcher.Run(stop)
+
+		bus.Publish(events.Event{Type: events.TypeJob, Kind: queue.KindInstall, State: queue.StateFailed, Universe: "prod"})
+		bus.Publish(events.Event{Type: events.TypeJob, Kind: queue.KindInstall, State: queue.StateSucceeded, Universe: "dev"})
+		bus.Publish(events.Event{Type: events.TypeJob, Kind: queue.KindInstall, State: queue.StateSucceeded, Universe: "prod", Package: "nginx", Job: "42"})
+
+		var received delivery
+		Eventually(deliveries, 5*time.Second).Should(Receive(&received))
+		Expect(received.header.Get(webhooks.SignatureHeader)).To(Equal(webhooks.Sign("s3cr3t", received.body)))
+		Expect(received.header.Get(webhooks.EventHeader)).To(Equal(webhooks.EventInstallSucceeded))
+
+		var payload webhooks.Payload
+		Expect(json.Unmarshal(received.body, &payload)).To(Succeed())
+		Expect(payload.Package).To(Equal("nginx"))
+		Expect(payload.Job).To(Equal("42"))
+
+		Eventually(func() string {
+			deliveries := dispatcher.Deliveries("chatbot")
+			if len(deliveries) != 1 {
+				return ""
+			}
+			return deliveries[0].State
+		}).Should(Equal(webhooks.StateDelivered))
+		Expect(dispatcher.Deliveries("chatbot")[0].Attempts).To(Equal(2))
+		Consistently(deliveries).ShouldNot(Receive())
+	})
+
+	It("rejects unknown events", func() {
+		Expect(webhooks.Validate(&provider.Webhook{Events: []string{"install.started"}})).To(Equal(webhooks.ErrUnknownEvent("install.started")))
+	})
+})